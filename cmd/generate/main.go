@@ -1,71 +1,196 @@
 package main
 
 import (
+	"errors"
 	"flag"
+	"fmt"
 	generator "github.com/awesome-jellyfin/clients-md-generator"
 	"io"
 	"os"
 )
 
-func checkFileExistsOrPanic(filePath string) {
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		panic("File does not exist: " + filePath)
-	}
+const (
+	// exitConfigError is the exit code for invalid or unreadable config files.
+	exitConfigError = 1
+	// exitRenderError is the exit code for errors while generating the output.
+	exitRenderError = 2
+)
+
+const (
+	formatMarkdown = "markdown"
+	formatJSON     = "json"
+	formatHTML     = "html"
+)
+
+// exitError is an error which terminates the program with a specific exit code.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+func configError(err error) error {
+	return &exitError{code: exitConfigError, err: err}
+}
+
+func renderError(err error) error {
+	return &exitError{code: exitRenderError, err: err}
+}
+
+// options holds the command line options.
+type options struct {
+	inputFile      string
+	outputFile     string
+	outputStdout   bool
+	format         string
+	checkIconFiles bool
+	iconsDir       string
+	baseURL        string
+	toc            bool
+	hideDeprecated bool
+	accessible     bool
+	validate       bool
+	printSchema    bool
+	filter         generator.ClientFilter
 }
 
 func main() {
-	var inputFile string
-	flag.StringVar(&inputFile, "input", "clients.yaml", "input file (required)")
+	var opts options
+	flag.StringVar(&opts.inputFile, "input", "clients.yaml", "input file (required)")
 
 	// outputs
-	var outputFile string
-	var outputStdout bool
-	flag.StringVar(&outputFile, "out-file", "", "output file (leave empty for dry run)")
-	flag.BoolVar(&outputStdout, "out-stdout", true, "output to stdout")
+	flag.StringVar(&opts.outputFile, "out-file", "", "output file (leave empty for dry run)")
+	flag.BoolVar(&opts.outputStdout, "out-stdout", true, "output to stdout")
+	flag.StringVar(&opts.format, "format", formatMarkdown, "output format (markdown, json or html)")
 
 	// other
-	var checkIconFiles bool
-	flag.BoolVar(&checkIconFiles, "check-icons", false, "check if icons exist")
+	flag.BoolVar(&opts.checkIconFiles, "check-icons", false, "check if icons exist and report unused icons")
+	flag.StringVar(&opts.iconsDir, "icons-dir", generator.DefaultIconsDir, "directory checked for unused icons")
+	flag.StringVar(&opts.baseURL, "base-url", "", "base URL prepended to relative icon paths")
+	flag.BoolVar(&opts.toc, "toc", false, "prepend a table of contents")
+	flag.BoolVar(&opts.hideDeprecated, "hide-deprecated", false, "exclude deprecated clients")
+	flag.BoolVar(&opts.accessible, "accessible", false, "add \"Yes\" or \"No\" to the OSS, Free and Paid symbols")
+	flag.Func("only-target", "only include clients of this target (repeatable, any matches)", func(val string) error {
+		opts.filter.Targets = append(opts.filter.Targets, val)
+		return nil
+	})
+	flag.Func("only-type", "only include clients of this type key or badge (repeatable, any matches)", func(val string) error {
+		opts.filter.Types = append(opts.filter.Types, val)
+		return nil
+	})
+	flag.BoolVar(&opts.validate, "validate", false, "only check the config and that all downloads render, without writing output")
+	flag.BoolVar(&opts.printSchema, "schema", false, "print the JSON Schema of the config file and exit")
 	flag.Parse()
 
+	if err := run(opts); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+
+		code := exitConfigError
+		var exitErr *exitError
+		if errors.As(err, &exitErr) {
+			code = exitErr.code
+		}
+		os.Exit(code)
+	}
+}
+
+func run(opts options) error {
+	if opts.printSchema {
+		if err := generator.WriteConfigSchema(os.Stdout); err != nil {
+			return renderError(err)
+		}
+		return nil
+	}
+
+	if opts.format != formatMarkdown && opts.format != formatJSON && opts.format != formatHTML {
+		return configError(fmt.Errorf("unknown output format: %q", opts.format))
+	}
+
 	// parse clients.yaml file
-	config, err := generator.LoadConfig(inputFile)
+	config, err := generator.LoadConfigWithIncludes(opts.inputFile)
 	if err != nil {
-		panic(err)
+		return configError(err)
+	}
+	if opts.validate {
+		// Report the downloads per client first, as ValidateConfig also rejects broken downloads
+		if errs := generator.ValidateDownloads(config); len(errs) > 0 {
+			return renderError(errors.Join(errs...))
+		}
+	}
+	if errs := generator.ValidateConfig(config); len(errs) > 0 {
+		return configError(errors.Join(errs...))
+	}
+	if opts.validate {
+		return nil
 	}
 
-	// check icon files
-	if checkIconFiles {
-		for _, i := range config.Icons {
-			if i.Dark != "" {
-				checkFileExistsOrPanic(i.Dark)
-			}
-			if i.Light != "" {
-				checkFileExistsOrPanic(i.Light)
-			}
-			if i.Single != "" {
-				checkFileExistsOrPanic(i.Single)
+	// check icon files before filtering, so icons of excluded clients are not reported as unused
+	if opts.checkIconFiles {
+		missing, unused, err := generator.CheckIcons(config, opts.iconsDir)
+		if err != nil {
+			return configError(err)
+		}
+		for _, path := range unused {
+			fmt.Fprintln(os.Stderr, "unused icon: "+path)
+		}
+		if len(missing) > 0 {
+			errs := make([]error, 0, len(missing))
+			for _, path := range missing {
+				errs = append(errs, errors.New("missing icon: "+path))
 			}
+			return configError(errors.Join(errs...))
 		}
 	}
 
+	if opts.toc {
+		config.TOC = true
+	}
+	if opts.accessible {
+		config.Accessible = true
+	}
+	if opts.baseURL != "" {
+		config.BaseURL = opts.baseURL
+	}
+	opts.filter.Apply(config)
+	if opts.hideDeprecated {
+		config.Clients = generator.FilterClients(config.Clients, func(client *generator.Client) bool {
+			return !generator.Deref(client.Deprecated)
+		})
+	}
+
 	var writers []io.Writer
-	if outputFile != "" {
-		f, err := os.OpenFile(outputFile, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0755)
+	if opts.outputFile != "" {
+		f, err := os.OpenFile(opts.outputFile, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0755)
 		if err != nil {
-			panic(err)
+			return renderError(err)
 		}
 		defer f.Close()
 
 		writers = append(writers, f)
 	}
 
-	if outputStdout {
+	if opts.outputStdout {
 		writers = append(writers, os.Stdout)
 	}
 
 	writer := io.MultiWriter(writers...)
-	if err = generator.CreateMarkdownDocument(writer, config); err != nil {
-		panic(err)
+	switch opts.format {
+	case formatMarkdown:
+		err = generator.CreateMarkdownDocument(writer, config)
+	case formatJSON:
+		err = generator.CreateJSONDocument(writer, config)
+	case formatHTML:
+		err = generator.CreateHTMLDocument(writer, config)
+	}
+	if err != nil {
+		return renderError(err)
 	}
+	return nil
 }
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeConfig writes a config file to a temporary directory and returns its path.
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// exitCode returns the exit code of an error returned by run.
+func exitCode(t *testing.T, err error) int {
+	t.Helper()
+	var exitErr *exitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("expected an exit error, got %v", err)
+	}
+	return exitErr.code
+}
+
+// captureStderr returns everything written to stderr while calling fn.
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr = w
+	defer func() { os.Stderr = stderr }()
+
+	fn()
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestRunValidateBrokenDownload(t *testing.T) {
+	input := writeConfig(t, "clients.yaml", `
+icons:
+  play:
+    single: icons/play.png
+targets:
+  - key: mobile
+    display: Mobile
+    has:
+      - name: android
+clients:
+  - name: Broken
+    targets: [android]
+    downloads:
+      - icon: playstore
+        url: https://play.google.com
+`)
+
+	err := run(options{inputFile: input, format: formatMarkdown, validate: true})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if code := exitCode(t, err); code != exitRenderError {
+		t.Errorf("expected exit code %d, got %d: %v", exitRenderError, code, err)
+	}
+}
+
+func TestRunCheckIconsIgnoresFilters(t *testing.T) {
+	iconsDir := t.TempDir()
+	for _, name := range []string{"active.png", "deprecated.png"} {
+		if err := os.WriteFile(filepath.Join(iconsDir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	input := writeConfig(t, "clients.yaml", `
+types:
+  - key: Deprecated
+targets:
+  - key: mobile
+    display: Mobile
+    has:
+      - name: android
+clients:
+  - name: Active
+    targets: [android]
+    downloads:
+      - icon-url: `+filepath.Join(iconsDir, "active.png")+`
+        url: https://example.com/active
+  - name: Deprecated
+    targets: [android]
+    deprecated: true
+    downloads:
+      - icon-url: `+filepath.Join(iconsDir, "deprecated.png")+`
+        url: https://example.com/deprecated
+`)
+
+	var err error
+	stderr := captureStderr(t, func() {
+		err = run(options{
+			inputFile:      input,
+			format:         formatMarkdown,
+			checkIconFiles: true,
+			iconsDir:       iconsDir,
+			hideDeprecated: true,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(stderr, "unused icon") {
+		t.Errorf("expected no unused icons, got %q", stderr)
+	}
+}
+
+func TestRunConfigErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"missing file", filepath.Join(t.TempDir(), "missing.yaml")},
+		{"malformed yaml", writeConfig(t, "clients.yaml", "clients: [\n  - name: Broken\n")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := run(options{inputFile: tt.input, format: formatMarkdown})
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if code := exitCode(t, err); code != exitConfigError {
+				t.Errorf("expected exit code %d, got %d: %v", exitConfigError, code, err)
+			}
+		})
+	}
+}
+
+func TestRunEmptyEntries(t *testing.T) {
+	const targets = "targets: [{key: mobile, display: Mobile, has: [{name: android}]}]\n"
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"icon", "icons: {play: }\n" + targets, `icon "play": empty`},
+		{"client", targets + "clients:\n  -\n", "client #1: empty entry"},
+		{"target group", "targets:\n  -\n", "target group #1: empty"},
+		{"type", "types:\n  -\n", "type #1: empty"},
+		{"download", targets + "clients:\n  - name: A\n    targets: [android]\n    downloads:\n      -\n",
+			"download #1: empty"},
+	}
+	for _, tt := range tests {
+		for _, validate := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s/validate=%t", tt.name, validate), func(t *testing.T) {
+				input := writeConfig(t, "clients.yaml", tt.content)
+
+				err := run(options{inputFile: input, format: formatMarkdown, validate: validate})
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if code := exitCode(t, err); code != exitConfigError {
+					t.Errorf("expected exit code %d, got %d: %v", exitConfigError, code, err)
+				}
+				if !strings.Contains(err.Error(), tt.want) {
+					t.Errorf("expected %q, got %q", tt.want, err)
+				}
+			})
+		}
+	}
+}
+
+func TestRunUnknownFormat(t *testing.T) {
+	err := run(options{inputFile: "clients.yaml", format: "pdf"})
+	if err == nil || exitCode(t, err) != exitConfigError {
+		t.Errorf("expected a config error, got %v", err)
+	}
+}
+
+func TestRunWritesOutputFile(t *testing.T) {
+	input := writeConfig(t, "clients.yaml", `
+targets:
+  - key: web
+    display: Browser
+    has:
+      - name: web
+clients:
+  - name: Client
+    targets: [web]
+    website: https://example.com
+`)
+	output := filepath.Join(t.TempDir(), "clients.md")
+
+	if err := run(options{inputFile: input, outputFile: output, format: formatMarkdown}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "| [Client](https://example.com) |") {
+		t.Errorf("expected the client table, got:\n%s", data)
+	}
+}
@@ -0,0 +1,229 @@
+package generator
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	NameColumnKey        = "name"
+	OSSColumnKey         = "oss"
+	FreeColumnKey        = "free"
+	PaidColumnKey        = "paid"
+	DownloadsColumnKey   = "downloads"
+	BetaColumnKey        = "beta"
+	PlatformsColumnKey   = "platforms"
+	DescriptionColumnKey = "description"
+	LicenseColumnKey     = "license"
+	ActivityColumnKey    = "activity"
+	CommunityColumnKey   = "community"
+	StarsColumnKey       = "stars"
+	ServerColumnKey      = "server"
+)
+
+// CommunityBadges maps community link types to the shields rendered in the community column.
+// Links of other types are printed as text.
+var CommunityBadges = map[string]string{
+	"matrix":  "https://img.shields.io/badge/Matrix-000000?logo=matrix&logoColor=white",
+	"discord": "https://img.shields.io/badge/Discord-5865F2?logo=discord&logoColor=white",
+	"irc":     "https://img.shields.io/badge/IRC-grey?logo=liberadotchat&logoColor=white",
+}
+
+// DefaultColumns are the table columns printed if no columns are configured.
+var DefaultColumns = []string{
+	NameColumnKey,
+	OSSColumnKey,
+	FreeColumnKey,
+	PaidColumnKey,
+	DownloadsColumnKey,
+}
+
+// Column defines a column of the client tables.
+type Column struct {
+	Header string
+	// Cell generates the markdown content of the column for a client.
+	Cell func(client *ClientView, config *ClientsConfig) (string, error)
+}
+
+// Columns maps column keys to their definition.
+var Columns = map[string]*Column{
+	NameColumnKey: {
+		Header: "Name",
+		Cell:   nameCell,
+	},
+	OSSColumnKey: {
+		Header: "OSS",
+		Cell: func(client *ClientView, config *ClientsConfig) (string, error) {
+			return booleanCell(client.IsOpenSource, GoodTrueSymbolKey, BadFalseSymbolKey, config), nil
+		},
+	},
+	FreeColumnKey: {
+		Header: "Free",
+		Cell: func(client *ClientView, config *ClientsConfig) (string, error) {
+			return booleanCell(client.IsFree, GoodTrueSymbolKey, BadFalseSymbolKey, config), nil
+		},
+	},
+	PaidColumnKey: {
+		Header: "Paid",
+		Cell: func(client *ClientView, config *ClientsConfig) (string, error) {
+			return booleanCell(client.IsPaid, BadTrueSymbolKey, GoodFalseSymbolKey, config), nil
+		},
+	},
+	DownloadsColumnKey: {
+		Header: "Downloads",
+		Cell: func(client *ClientView, config *ClientsConfig) (string, error) {
+			return processClientDownloads(client.Client, config)
+		},
+	},
+	BetaColumnKey: {
+		Header: "Beta",
+		Cell: func(client *ClientView, config *ClientsConfig) (string, error) {
+			return processClientBetaDownloads(client.Client, config)
+		},
+	},
+	PlatformsColumnKey: {
+		Header: "Platforms",
+		Cell:   platformsCell,
+	},
+	DescriptionColumnKey: {
+		Header: "Description",
+		Cell: func(client *ClientView, _ *ClientsConfig) (string, error) {
+			return escapeMarkdown(strings.TrimSpace(client.Description)), nil
+		},
+	},
+	LicenseColumnKey: {
+		Header: "License",
+		Cell:   licenseCell,
+	},
+	ActivityColumnKey: {
+		Header: "Activity",
+		Cell:   activityCell,
+	},
+	CommunityColumnKey: {
+		Header: "Community",
+		Cell:   communityCell,
+	},
+	StarsColumnKey: {
+		Header: "Stars",
+		Cell:   starsCell,
+	},
+	ServerColumnKey: {
+		Header: "Min. Server",
+		Cell: func(client *ClientView, _ *ClientsConfig) (string, error) {
+			return escapeMarkdown(strings.TrimSpace(client.MinServerVersion)), nil
+		},
+	},
+}
+
+// TableColumns returns the configured table columns or the default columns if none are configured.
+func (c *ClientsConfig) TableColumns() ([]*Column, error) {
+	keys := Select(len(c.Columns) > 0, c.Columns, DefaultColumns)
+
+	columns := make([]*Column, 0, len(keys))
+	for _, key := range keys {
+		column, ok := Columns[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown column: %q%s", key, didYouMean(key, SortedKeys(Columns)))
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// nameCell generates the linked client name followed by its type badges.
+func nameCell(client *ClientView, config *ClientsConfig) (string, error) {
+	name := escapeMarkdown(client.DisplayName())
+	if client.IsDeprecated {
+		name = "~~" + name + "~~"
+	}
+
+	var badges []string
+	for _, t := range client.TypeKeys() {
+		if err := addTypeBadge(&badges, t, config); err != nil {
+			return "", fmt.Errorf("client %q: %w", client.Name, err)
+		}
+	}
+
+	for _, b := range badges {
+		name += fmt.Sprintf(" ` %s `", b)
+	}
+	if client.AnchorLink != "" {
+		return fmt.Sprintf("[%s](%s)", name, client.AnchorLink), nil
+	}
+	link := fmt.Sprintf("[%s](%s)", name, client.WebsiteURL)
+	if client.Anchor != "" {
+		link = fmt.Sprintf(`<a id="%s"></a>%s`, client.Anchor, link)
+	}
+	return link, nil
+}
+
+// booleanCell generates the symbol of a yes/no column.
+// In accessible mode, the symbol is followed by "Yes" or "No" for screen readers.
+func booleanCell(value bool, trueKey, falseKey string, config *ClientsConfig) string {
+	symbol := config.Symbol(Select(value, trueKey, falseKey))
+	if config.Accessible {
+		symbol += Select(value, " Yes", " No")
+	}
+	return symbol
+}
+
+// platformsCell generates a comma-separated list of the display names of the client's targets.
+// Targets which are not defined in any target group are printed as-is.
+func platformsCell(client *ClientView, config *ClientsConfig) (string, error) {
+	platforms := make([]string, 0, len(client.Targets))
+	for _, name := range client.Targets {
+		if group, target, ok := config.FindTarget(name); ok {
+			name = Select(target.Mapped != "", target.Mapped, group.Display)
+		}
+		platforms = append(platforms, escapeMarkdown(strings.TrimSpace(name)))
+	}
+	return strings.Join(platforms, ", "), nil
+}
+
+// licenseCell generates the SPDX license identifier of the client.
+// The identifier is linked to the repository if the client is hosted on GitHub,
+// as the name of the license file differs between repositories.
+func licenseCell(client *ClientView, _ *ClientsConfig) (string, error) {
+	license := escapeMarkdown(strings.TrimSpace(client.License))
+	if license == "" {
+		return "", nil
+	}
+	if owner, repo, ok := ParseGitHubRepo(client.OpenSourceURL); ok {
+		return fmt.Sprintf("[%s](%s)", license, GitHubRepoURL(owner, repo)), nil
+	}
+	return license, nil
+}
+
+// activityCell generates a last commit badge if the client is hosted on GitHub.
+func activityCell(client *ClientView, _ *ClientsConfig) (string, error) {
+	owner, repo, ok := ParseGitHubRepo(client.OpenSourceURL)
+	if !ok {
+		return "", nil
+	}
+	return fmt.Sprintf("![last commit](https://img.shields.io/github/last-commit/%s/%s)",
+		url.PathEscape(owner), url.PathEscape(repo)), nil
+}
+
+// starsCell generates a GitHub stars badge if the client is hosted on GitHub.
+func starsCell(client *ClientView, _ *ClientsConfig) (string, error) {
+	owner, repo, ok := ParseGitHubRepo(client.OpenSourceURL)
+	if !ok {
+		return "", nil
+	}
+	return fmt.Sprintf("![stars](https://img.shields.io/github/stars/%s/%s)",
+		url.PathEscape(owner), url.PathEscape(repo)), nil
+}
+
+// communityCell generates a badge for each community link of the client.
+func communityCell(client *ClientView, _ *ClientsConfig) (string, error) {
+	links := make([]string, 0, len(client.Community))
+	for _, link := range client.Community {
+		if badge, ok := CommunityBadges[link.Type]; ok {
+			links = append(links, fmt.Sprintf("[![%s](%s)](%s)", link.Type, badge, link.URL))
+		} else {
+			links = append(links, fmt.Sprintf("[%s](%s)", escapeMarkdown(link.Type), link.URL))
+		}
+	}
+	return strings.Join(links, " "), nil
+}
@@ -0,0 +1,278 @@
+package generator
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLicenseCell(t *testing.T) {
+	tests := []struct {
+		name   string
+		client *Client
+		want   string
+	}{
+		{"github", &Client{License: "MIT", OpenSourceURL: "https://github.com/owner/repo#readme"}, "[MIT](https://github.com/owner/repo)"},
+		{"other host", &Client{License: "GPL-2.0", OpenSourceURL: "https://gitlab.com/owner/repo"}, "GPL-2.0"},
+		{"no license", &Client{OpenSourceURL: "https://github.com/owner/repo"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := licenseCell(ResolveClient(tt.client), &ClientsConfig{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestServerColumn(t *testing.T) {
+	config := testConfig()
+	config.Columns = []string{NameColumnKey, ServerColumnKey}
+	client := &Client{Name: "Client", Website: "https://example.com", MinServerVersion: " 10.8 "}
+
+	row, err := RenderClientRow(client, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "| [Client](https://example.com) | 10.8 |"; row != want {
+		t.Errorf("expected %q, got %q", want, row)
+	}
+}
+
+func TestCustomColumnSubset(t *testing.T) {
+	config := testConfig()
+	config.Columns = []string{DownloadsColumnKey, NameColumnKey}
+
+	var buf bytes.Buffer
+	if err := PrintTableHeader(&buf, config); err != nil {
+		t.Fatal(err)
+	}
+	if want := "| Downloads | Name |\n| --------- | ---- |\n"; buf.String() != want {
+		t.Errorf("expected header %q, got %q", want, buf.String())
+	}
+
+	client := &Client{Name: "Client", Website: "https://example.com", Downloads: []*Hoster{{Text: "APK", URL: "https://example.com/app.apk"}}}
+	row, err := RenderClientRow(client, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "| [APK](https://example.com/app.apk) | [Client](https://example.com) |"; row != want {
+		t.Errorf("expected row %q, got %q", want, row)
+	}
+}
+
+func TestDefaultAndUnknownColumns(t *testing.T) {
+	columns, err := (&ClientsConfig{}).TableColumns()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(columns) != len(DefaultColumns) {
+		t.Errorf("expected %d default columns, got %d", len(DefaultColumns), len(columns))
+	}
+
+	if _, err := (&ClientsConfig{Columns: []string{"name", "stars", "rating"}}).TableColumns(); err == nil ||
+		!strings.Contains(err.Error(), `unknown column: "rating"`) {
+		t.Errorf("expected unknown column error, got %v", err)
+	}
+}
+
+func TestPlatformsCell(t *testing.T) {
+	config := testConfig()
+	config.Targets = append(config.Targets, &TargetGroup{Key: "tv", Display: "TV", Has: []*Target{{Name: "roku"}}})
+
+	tests := []struct {
+		name    string
+		targets []string
+		want    string
+	}{
+		{"mapped names", []string{"android", "IOS"}, "Android, iOS"},
+		{"group display name", []string{"roku"}, "TV"},
+		{"unknown target", []string{"web", " xbox_one "}, `Web, xbox\_one`},
+		{"no targets", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := platformsCell(ResolveClient(&Client{Targets: tt.targets}), config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDescriptionColumn(t *testing.T) {
+	config := testConfig()
+	config.Columns = []string{NameColumnKey, DescriptionColumnKey}
+
+	described := &Client{Name: "Described", Website: "https://example.com", Description: " A *fast* client. "}
+	row, err := RenderClientRow(described, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `| [Described](https://example.com) | A \*fast\* client. |`; row != want {
+		t.Errorf("expected %q, got %q", want, row)
+	}
+
+	undescribed := &Client{Name: "Undescribed", Website: "https://example.com"}
+	row, err = RenderClientRow(undescribed, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "| [Undescribed](https://example.com) |  |"; row != want {
+		t.Errorf("expected %q, got %q", want, row)
+	}
+}
+
+func TestActivityCell(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://github.com/jellyfin/jellyfin-web/", "![last commit](https://img.shields.io/github/last-commit/jellyfin/jellyfin-web)"},
+		{"https://github.com/jellyfin/jellyfin-web.git", "![last commit](https://img.shields.io/github/last-commit/jellyfin/jellyfin-web)"},
+		{"https://gitlab.com/owner/repo", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		got, err := activityCell(ResolveClient(&Client{OpenSourceURL: tt.url}), &ClientsConfig{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("activityCell(%q) = %q, expected %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestSymbolOverrides(t *testing.T) {
+	config := testConfig()
+	config.Columns = []string{OSSColumnKey, FreeColumnKey, PaidColumnKey}
+	config.Symbols = map[string]string{GoodTrueSymbolKey: "yes"}
+
+	tests := []struct {
+		client *Client
+		want   string
+	}{
+		{config.Clients[0], "| yes | yes | " + GoodFalse + " |"},
+		{config.Clients[2], "| " + BadFalse + " | " + BadFalse + " | " + BadTrue + " |"},
+	}
+	for _, tt := range tests {
+		row, err := RenderClientRow(tt.client, config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if row != tt.want {
+			t.Errorf("client %q: expected %q, got %q", tt.client.Name, tt.want, row)
+		}
+	}
+}
+
+func TestAccessibleBooleanCells(t *testing.T) {
+	config := testConfig()
+	config.Columns = []string{OSSColumnKey, FreeColumnKey, PaidColumnKey}
+	config.Accessible = true
+
+	tests := []struct {
+		client *Client
+		want   string
+	}{
+		{config.Clients[0], "| ✅ Yes | ✅ Yes | ❎ No |"},
+		{config.Clients[2], "| ❌ No | ❌ No | ☑️ Yes |"},
+	}
+	for _, tt := range tests {
+		row, err := RenderClientRow(tt.client, config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if row != tt.want {
+			t.Errorf("client %q: expected %q, got %q", tt.client.Name, tt.want, row)
+		}
+	}
+}
+
+func TestBetaColumn(t *testing.T) {
+	config := testConfig()
+	config.Columns = []string{NameColumnKey, DownloadsColumnKey, BetaColumnKey}
+
+	var buf bytes.Buffer
+	if err := PrintTableHeader(&buf, config); err != nil {
+		t.Fatal(err)
+	}
+	if want := "| Name | Downloads | Beta |\n"; !strings.HasPrefix(buf.String(), want) {
+		t.Errorf("expected header %q, got %q", want, buf.String())
+	}
+
+	client := &Client{
+		Name:          "Client",
+		Website:       "https://example.com",
+		Downloads:     []*Hoster{{Icon: "play", URL: "https://play.google.com"}},
+		BetaDownloads: []*Hoster{{Text: "TestFlight", URL: "https://testflight.apple.com"}},
+	}
+	row, err := RenderClientRow(client, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "| [Client](https://example.com) | [![img](icons/play.png)](https://play.google.com) | [TestFlight](https://testflight.apple.com) |"
+	if row != want {
+		t.Errorf("expected %q, got %q", want, row)
+	}
+
+	client.BetaDownloads = nil
+	row, err = RenderClientRow(client, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(row, " |  |") {
+		t.Errorf("expected an empty beta cell, got %q", row)
+	}
+}
+
+func TestCommunityCell(t *testing.T) {
+	client := &Client{
+		Name: "Client",
+		Community: []*CommunityLink{
+			{Type: "matrix", URL: "https://matrix.to/#/#client:matrix.org"},
+			{Type: "discord", URL: "https://discord.gg/client"},
+			{Type: "forum", URL: "https://forum.example.com"},
+		},
+	}
+
+	got, err := communityCell(ResolveClient(client), &ClientsConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "[![matrix](" + CommunityBadges["matrix"] + ")](https://matrix.to/#/#client:matrix.org) " +
+		"[![discord](" + CommunityBadges["discord"] + ")](https://discord.gg/client) " +
+		"[forum](https://forum.example.com)"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestStarsCell(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://github.com/jellyfin/jellyfin-web", "![stars](https://img.shields.io/github/stars/jellyfin/jellyfin-web)"},
+		{"https://www.github.com/jmshrv/finamp?tab=readme-ov-file", "![stars](https://img.shields.io/github/stars/jmshrv/finamp)"},
+		{"https://gitlab.com/owner/repo", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		got, err := starsCell(ResolveClient(&Client{OpenSourceURL: tt.url}), &ClientsConfig{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("starsCell(%q) = %q, expected %q", tt.url, got, tt.want)
+		}
+	}
+}
@@ -1,8 +1,17 @@
 package generator
 
 import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"gopkg.in/yaml.v3"
 	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
 )
 
@@ -15,14 +24,190 @@ const (
 	BadFalse       = "❌"
 )
 
-// LoadConfig reads and unmarshals the YAML config file.
+const (
+	GoodTrueSymbolKey  = "good-true"
+	BadTrueSymbolKey   = "bad-true"
+	GoodFalseSymbolKey = "good-false"
+	BadFalseSymbolKey  = "bad-false"
+)
+
+// DefaultSymbols maps the symbol keys to the symbols printed in the OSS, Free and Paid columns.
+var DefaultSymbols = map[string]string{
+	GoodTrueSymbolKey:  GoodTrue,
+	BadTrueSymbolKey:   BadTrue,
+	GoodFalseSymbolKey: GoodFalse,
+	BadFalseSymbolKey:  BadFalse,
+}
+
+const (
+	// SortNone keeps clients in the order they appear in the config.
+	SortNone = "none"
+	// SortName sorts clients by name, case-insensitively. This is the default.
+	SortName = "name"
+	// SortDownloads sorts clients by their number of download links, most first.
+	// Stable, beta and grouped downloads are counted.
+	SortDownloads = "downloads"
+)
+
+// LoadConfig reads and unmarshals the config file.
+// Environment variables referenced as ${VAR} or ${VAR:-default} in string values are expanded
+// after parsing; $${VAR} is kept as the literal ${VAR}.
+// Files with a .json extension are parsed as JSON, all other files as YAML.
 func LoadConfig(filename string) (config *ClientsConfig, err error) {
 	data, err := os.ReadFile(filename)
 	if err != nil {
 		return nil, err
 	}
-	err = yaml.Unmarshal(data, &config)
-	return
+	if strings.EqualFold(filepath.Ext(filename), ".json") {
+		err = json.Unmarshal(data, &config)
+	} else {
+		err = yaml.Unmarshal(data, &config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	if err := expandEnvValue(reflect.ValueOf(config)); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return config, nil
+}
+
+// envVarRegex matches ${VAR} and ${VAR:-default} environment variable references,
+// including the escaped form $${VAR}.
+var envVarRegex = regexp.MustCompile(`\$(\$?)\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?}`)
+
+// expandEnv replaces all environment variable references in `s`.
+// Referencing an unset variable without a default value is an error.
+func expandEnv(s string) (string, error) {
+	var errs []error
+	expanded := envVarRegex.ReplaceAllStringFunc(s, func(match string) string {
+		groups := envVarRegex.FindStringSubmatch(match)
+		if groups[1] != "" {
+			// Escaped reference
+			return match[1:]
+		}
+		hasDefault := strings.Contains(match, ":-")
+		if value, ok := os.LookupEnv(groups[2]); ok && (value != "" || !hasDefault) {
+			return value
+		}
+		if hasDefault {
+			return groups[3]
+		}
+		errs = append(errs, fmt.Errorf("environment variable %s is not set", groups[2]))
+		return match
+	})
+	return expanded, errors.Join(errs...)
+}
+
+// expandEnvValue expands the environment variable references in all strings reachable from `v`.
+// Map keys are not expanded.
+func expandEnvValue(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return expandEnvValue(v.Elem())
+	case reflect.String:
+		expanded, err := expandEnv(v.String())
+		if err != nil {
+			return err
+		}
+		v.SetString(expanded)
+	case reflect.Struct:
+		var errs []error
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				errs = append(errs, expandEnvValue(v.Field(i)))
+			}
+		}
+		return errors.Join(errs...)
+	case reflect.Slice, reflect.Array:
+		var errs []error
+		for i := 0; i < v.Len(); i++ {
+			errs = append(errs, expandEnvValue(v.Index(i)))
+		}
+		return errors.Join(errs...)
+	case reflect.Map:
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return cmp.Compare(a.String(), b.String())
+		})
+		var errs []error
+		for _, key := range keys {
+			// Map values are not addressable, so expand a copy and store it back
+			value := reflect.New(v.Type().Elem()).Elem()
+			value.Set(v.MapIndex(key))
+			errs = append(errs, expandEnvValue(value))
+			v.SetMapIndex(key, value)
+		}
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+// LoadConfigWithIncludes reads the config file and merges all config files listed in its includes.
+// Include paths are resolved relative to the including file.
+func LoadConfigWithIncludes(filename string) (*ClientsConfig, error) {
+	return loadConfigWithIncludes(filename, nil)
+}
+
+func loadConfigWithIncludes(filename string, parents []string) (*ClientsConfig, error) {
+	path, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, err
+	}
+	for _, parent := range parents {
+		if parent == path {
+			return nil, fmt.Errorf("cyclic include: %s", strings.Join(append(parents, path), " -> "))
+		}
+	}
+
+	config, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		config = &ClientsConfig{}
+	}
+
+	parents = append(parents[:len(parents):len(parents)], path)
+	for _, include := range config.Include {
+		if !filepath.IsAbs(include) {
+			include = filepath.Join(filepath.Dir(path), include)
+		}
+		included, err := loadConfigWithIncludes(include, parents)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filename, err)
+		}
+		config.merge(included)
+	}
+	config.Include = nil
+	return config, nil
+}
+
+// merge appends the clients, targets and types of `other` to the config.
+// Icons are only added if the config does not already define an icon with the same key.
+func (c *ClientsConfig) merge(other *ClientsConfig) {
+	c.Clients = append(c.Clients, other.Clients...)
+	c.Targets = append(c.Targets, other.Targets...)
+	c.Types = append(c.Types, other.Types...)
+	for key, icon := range other.Icons {
+		if c.Icons == nil {
+			c.Icons = make(map[string]*HosterIcon)
+		}
+		if _, ok := c.Icons[key]; !ok {
+			c.Icons[key] = icon
+		}
+	}
+	for key, symbol := range other.Symbols {
+		if c.Symbols == nil {
+			c.Symbols = make(map[string]string)
+		}
+		if _, ok := c.Symbols[key]; !ok {
+			c.Symbols[key] = symbol
+		}
+	}
 }
 
 // createIdentifierClientMap creates a map of identifiers to corresponding clients.
@@ -37,3 +222,55 @@ func createIdentifierClientMap(clients []*Client) map[string][]*Client {
 	}
 	return identifierClientMap
 }
+
+// clientsOfTarget returns the clients of a target from the identifier-client map.
+func clientsOfTarget(identifierClientMap map[string][]*Client, target string) []*Client {
+	return identifierClientMap[strings.TrimSpace(strings.ToLower(target))]
+}
+
+// clientsOfType returns all clients which have the type with the given key.
+func clientsOfType(clients []*Client, key string) []*Client {
+	var typeClients []*Client
+	for _, client := range clients {
+		for _, clientType := range client.Types {
+			if clientType == key {
+				typeClients = append(typeClients, client)
+				break
+			}
+		}
+	}
+	return typeClients
+}
+
+// sortClients returns a sorted copy of `clients` according to the sort `mode`.
+// The sort is stable, so clients comparing equal keep their config order.
+func sortClients(clients []*Client, mode string) ([]*Client, error) {
+	sorted := make([]*Client, len(clients))
+	copy(sorted, clients)
+
+	switch mode {
+	case SortNone:
+	case "", SortName:
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return strings.ToLower(sorted[i].DisplayName()) < strings.ToLower(sorted[j].DisplayName())
+		})
+	case SortDownloads:
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].downloadCount() > sorted[j].downloadCount()
+		})
+	default:
+		return nil, fmt.Errorf("unknown sort mode: %q", mode)
+	}
+	return sorted, nil
+}
+
+// FilterClients returns all clients for which `keep` returns true.
+func FilterClients(clients []*Client, keep func(client *Client) bool) []*Client {
+	var filtered []*Client
+	for _, client := range clients {
+		if keep(client) {
+			filtered = append(filtered, client)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,259 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// writeTestFile writes a file into `dir` and returns its path.
+func writeTestFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfigExpandsEnv(t *testing.T) {
+	t.Setenv("CLIENTS_BASE_URL", "https://cdn.example.com")
+	t.Setenv("CLIENTS_EMPTY", "")
+	t.Setenv("CLIENTS_QUOTED", "a \"quoted\": value\nwith: newline")
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"set", "${CLIENTS_BASE_URL}/icons", "https://cdn.example.com/icons"},
+		{"default unused", "${CLIENTS_BASE_URL:-https://fallback}", "https://cdn.example.com"},
+		{"default unset", "${CLIENTS_UNSET:-https://fallback}", "https://fallback"},
+		{"default empty", "${CLIENTS_EMPTY:-https://fallback}", "https://fallback"},
+		{"empty", "${CLIENTS_EMPTY}", ""},
+		{"escaped", "$${CLIENTS_UNSET}", "${CLIENTS_UNSET}"},
+		{"structural characters", "${CLIENTS_QUOTED}", "a \"quoted\": value\nwith: newline"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTestFile(t, t.TempDir(), "clients.yaml", "base-url: '"+tt.value+"'\n")
+			config, err := LoadConfig(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if config.BaseURL != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, config.BaseURL)
+			}
+		})
+	}
+}
+
+func TestLoadConfigExpandsEnvInNestedValues(t *testing.T) {
+	t.Setenv("CLIENTS_ICON", "icons/play.png")
+	path := writeTestFile(t, t.TempDir(), "clients.yaml", `
+icons:
+  play:
+    single: ${CLIENTS_ICON}
+symbols:
+  good-true: ${CLIENTS_SYMBOL:-yes}
+clients:
+  - name: Client
+    downloads:
+      - icon-url: ${CLIENTS_ICON}
+`)
+	config, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := config.Icons["play"].Single; got != "icons/play.png" {
+		t.Errorf("expected expanded icon, got %q", got)
+	}
+	if got := config.Symbols[GoodTrueSymbolKey]; got != "yes" {
+		t.Errorf("expected expanded symbol, got %q", got)
+	}
+	if got := config.Clients[0].Downloads[0].IconURL; got != "icons/play.png" {
+		t.Errorf("expected expanded icon-url, got %q", got)
+	}
+}
+
+func TestLoadConfigUnsetEnv(t *testing.T) {
+	path := writeTestFile(t, t.TempDir(), "clients.yaml", "base-url: ${CLIENTS_UNSET}\n")
+	_, err := LoadConfig(path)
+	if err == nil {
+		t.Fatal("expected an error for an unset variable")
+	}
+	if !strings.Contains(err.Error(), "CLIENTS_UNSET is not set") {
+		t.Errorf("expected unset variable error, got %v", err)
+	}
+}
+
+func TestLoadConfigIgnoresEnvInComments(t *testing.T) {
+	path := writeTestFile(t, t.TempDir(), "clients.yaml", "# uses ${CLIENTS_UNSET}\ntitle: Clients\n")
+	config, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Title != "Clients" {
+		t.Errorf("expected title %q, got %q", "Clients", config.Title)
+	}
+}
+
+func TestSortClients(t *testing.T) {
+	clients := []*Client{
+		{Name: "beta", Downloads: []*Hoster{{}}},
+		{Name: "Alpha"},
+		{Name: "  charlie", Downloads: []*Hoster{{}, {}}},
+		{Name: "delta", BetaDownloads: []*Hoster{{}}, DownloadGroups: []*DownloadGroup{{Downloads: []*Hoster{{}, {}}}}},
+	}
+
+	tests := []struct {
+		mode string
+		want []string
+	}{
+		{"", []string{"Alpha", "beta", "  charlie", "delta"}},
+		{SortName, []string{"Alpha", "beta", "  charlie", "delta"}},
+		{SortNone, []string{"beta", "Alpha", "  charlie", "delta"}},
+		{SortDownloads, []string{"delta", "  charlie", "beta", "Alpha"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			sorted, err := sortClients(clients, tt.mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			names := make([]string, 0, len(sorted))
+			for _, client := range sorted {
+				names = append(names, client.Name)
+			}
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("expected %q, got %q", tt.want, names)
+			}
+		})
+	}
+
+	if _, err := sortClients(clients, "stars"); err == nil {
+		t.Error("expected an error for an unknown sort mode")
+	}
+}
+
+func TestLoadConfigJSONAndYAMLAreEquivalent(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := writeTestFile(t, dir, "clients.yaml", `
+types:
+  - key: Official
+    badge: "🔹"
+targets:
+  - key: web
+    display: Browser
+    has:
+      - name: web
+        mapped: Web
+icons:
+  github:
+    dark: icons/gh-dark.png
+    light: icons/gh-light.png
+columns: [name, oss, free, paid, downloads, license]
+clients:
+  - name: Jellyfin Web
+    targets: [web]
+    oss: https://github.com/jellyfin/jellyfin-web
+    license: GPL-2.0
+    price:
+      free: true
+    downloads:
+      - icon: github
+`)
+	jsonPath := writeTestFile(t, dir, "clients.json", `{
+  "types": [{"key": "Official", "badge": "🔹"}],
+  "targets": [{"key": "web", "display": "Browser", "has": [{"name": "web", "mapped": "Web"}]}],
+  "icons": {"github": {"dark": "icons/gh-dark.png", "light": "icons/gh-light.png"}},
+  "columns": ["name", "oss", "free", "paid", "downloads", "license"],
+  "clients": [{
+    "name": "Jellyfin Web",
+    "targets": ["web"],
+    "oss": "https://github.com/jellyfin/jellyfin-web",
+    "license": "GPL-2.0",
+    "price": {"free": true},
+    "downloads": [{"icon": "github"}]
+  }]
+}`)
+
+	render := func(path string) string {
+		config, err := LoadConfig(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := CreateMarkdownDocument(&buf, config); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+	yamlOutput, jsonOutput := render(yamlPath), render(jsonPath)
+	if yamlOutput != jsonOutput {
+		t.Errorf("expected identical output, got YAML:\n%s\nJSON:\n%s", yamlOutput, jsonOutput)
+	}
+	if !strings.Contains(yamlOutput, "Jellyfin Web") {
+		t.Errorf("expected the client in the output, got:\n%s", yamlOutput)
+	}
+}
+
+func TestLoadConfigWithIncludes(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "clients"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, filepath.Join(dir, "clients"), "music.yaml", `
+icons:
+  play:
+    single: icons/other.png
+  fdroid:
+    single: icons/fdroid.png
+clients:
+  - name: Finamp
+    targets: [android]
+`)
+	path := writeTestFile(t, dir, "clients.yaml", `
+include: [clients/music.yaml]
+icons:
+  play:
+    single: icons/play.png
+clients:
+  - name: Jellyfin Web
+    targets: [web]
+`)
+
+	config, err := LoadConfigWithIncludes(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Clients) != 2 || config.Clients[0].Name != "Jellyfin Web" || config.Clients[1].Name != "Finamp" {
+		t.Errorf("expected the clients of both files in order, got %d clients", len(config.Clients))
+	}
+	if got := config.Icons["play"].Single; got != "icons/play.png" {
+		t.Errorf("expected the including file's icon to take precedence, got %q", got)
+	}
+	if _, ok := config.Icons["fdroid"]; !ok {
+		t.Error("expected the included icon to be merged")
+	}
+	if config.Include != nil {
+		t.Errorf("expected includes to be resolved, got %v", config.Include)
+	}
+}
+
+func TestLoadConfigWithIncludesCycle(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "a.yaml", "include: [b.yaml]\n")
+	writeTestFile(t, dir, "b.yaml", "include: [a.yaml]\n")
+
+	_, err := LoadConfigWithIncludes(filepath.Join(dir, "a.yaml"))
+	if err == nil {
+		t.Fatal("expected a cyclic include error")
+	}
+	if !strings.Contains(err.Error(), "cyclic include") {
+		t.Errorf("expected a cyclic include error, got %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+package generator
+
+import "strings"
+
+// sectionWriter writes the parts of the document body.
+// It is implemented by the markdown and the HTML document, which share the walk in writeDocument.
+type sectionWriter interface {
+	// environmentHeading writes the heading of the target sections.
+	environmentHeading() error
+	// targetGroupHeading writes the heading of a target group.
+	targetGroupHeading(group *TargetGroup) error
+	// targetHeading writes the sub-heading of a target in a group with sub-headings.
+	targetHeading(target *Target) error
+	// targetCaption writes the caption of a target in a group without sub-headings.
+	targetCaption(target *Target) error
+	// targetTable writes the client table of a target.
+	targetTable(clients []*Client, config *ClientsConfig) error
+	// typesHeading writes the heading of the type sections.
+	typesHeading() error
+	// typeHeading writes the heading of a type section.
+	typeHeading(customType *ClientType) error
+	// typeTable writes the client table of a type section.
+	typeTable(clients []*Client, config *ClientsConfig) error
+	// clientCount writes the number of clients of the following table.
+	clientCount(count int) error
+	// alphabetSection writes the list of all clients.
+	alphabetSection(clients []*Client) error
+	// legend writes the legend of the type badges.
+	legend(types ClientTypes) error
+}
+
+// writeDocument writes the document body.
+func writeDocument(writer sectionWriter, config *ClientsConfig) error {
+	// Process clients and create an identifier-client map
+	// e.g. iOS: [Swiftfin, Infuse, ...]
+	targetClientsMap := createIdentifierClientMap(config.Clients)
+
+	if err := writer.environmentHeading(); err != nil {
+		return err
+	}
+	for _, target := range config.Targets {
+		if target.Hidden {
+			continue
+		}
+		if err := writer.targetGroupHeading(target); err != nil {
+			return err
+		}
+		hasMultipleTargets := target.hasSubHeadings()
+		for _, meta := range target.Has {
+			if hasMultipleTargets {
+				if err := writer.targetHeading(meta); err != nil {
+					return err
+				}
+			} else if config.TargetCaptions && meta.Mapped != "" {
+				if err := writer.targetCaption(meta); err != nil {
+					return err
+				}
+			}
+			targetClients := clientsOfTarget(targetClientsMap, meta.Name)
+			if config.ShowCounts {
+				if err := writer.clientCount(len(targetClients)); err != nil {
+					return err
+				}
+			}
+			if err := writer.targetTable(targetClients, config); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Generate Type sections
+	printHeader := true
+	for _, customType := range config.Types {
+		if !customType.Section {
+			continue
+		}
+		if printHeader {
+			printHeader = false
+
+			if err := writer.typesHeading(); err != nil {
+				return err
+			}
+		}
+		typeClients := clientsOfType(config.Clients, customType.Key)
+		if len(typeClients) == 0 {
+			continue
+		}
+		if err := writer.typeHeading(customType); err != nil {
+			return err
+		}
+		if config.ShowCounts {
+			if err := writer.clientCount(len(typeClients)); err != nil {
+				return err
+			}
+		}
+		if err := writer.typeTable(typeClients, config); err != nil {
+			return err
+		}
+	}
+
+	if config.HasSection(SectionAlphabet) {
+		if err := writer.alphabetSection(alphabetClients(config)); err != nil {
+			return err
+		}
+	}
+
+	// Generate Type legend
+	if len(config.Types) > 0 {
+		if err := writer.legend(config.Types); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// alphabetClients returns all clients sorted by name.
+// Clients with the same name are only listed once.
+func alphabetClients(config *ClientsConfig) []*Client {
+	// sorting by name never fails
+	clients, _ := sortClients(config.Clients, SortName)
+
+	var unique []*Client
+	seen := make(map[string]bool)
+	for _, client := range clients {
+		key := strings.ToLower(client.DisplayName())
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, client)
+	}
+	return unique
+}
@@ -0,0 +1,20 @@
+package generator
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAlphabetClients(t *testing.T) {
+	config := testConfig()
+	config.Clients = append(config.Clients, &Client{Name: " Finamp", Website: "https://example.com/finamp"})
+
+	var names []string
+	for _, client := range alphabetClients(config) {
+		names = append(names, client.DisplayName())
+	}
+	want := []string{"Abandoned", "finamp", "Jellyfin Web"}
+	if !slices.Equal(names, want) {
+		t.Errorf("expected %q, got %q", want, names)
+	}
+}
@@ -0,0 +1,105 @@
+package generator
+
+import "strings"
+
+// ClientFilter restricts the generated document to clients of specific targets and types.
+// A client matches if it supports any of the targets AND has any of the types.
+// An empty list of targets or types matches all clients.
+type ClientFilter struct {
+	// Targets are target names, e.g. "ios".
+	Targets []string
+	// Types are type keys or badges.
+	Types []string
+}
+
+// IsEmpty returns true if the filter matches all clients.
+func (f *ClientFilter) IsEmpty() bool {
+	return len(f.Targets) == 0 && len(f.Types) == 0
+}
+
+// Matches returns true if the client matches the filter.
+func (f *ClientFilter) Matches(client *Client, config *ClientsConfig) bool {
+	return f.matchesTargets(client) && f.matchesTypes(client, config)
+}
+
+func (f *ClientFilter) matchesTargets(client *Client) bool {
+	if len(f.Targets) == 0 {
+		return true
+	}
+	for _, target := range client.Targets {
+		if f.hasTarget(target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *ClientFilter) matchesTypes(client *Client, config *ClientsConfig) bool {
+	if len(f.Types) == 0 {
+		return true
+	}
+	for _, key := range ResolveClient(client).TypeKeys() {
+		if t, ok := config.Types.FindType(key); ok && f.hasType(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *ClientFilter) hasTarget(name string) bool {
+	name = strings.TrimSpace(strings.ToLower(name))
+	for _, target := range f.Targets {
+		if strings.TrimSpace(strings.ToLower(target)) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *ClientFilter) hasType(t *ClientType) bool {
+	for _, val := range f.Types {
+		if val == t.Key || (t.Badge != "" && val == t.Badge) {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply removes all clients not matching the filter from the config.
+// Targets and type sections which are not part of the filter are removed as well.
+func (f *ClientFilter) Apply(config *ClientsConfig) {
+	if f.IsEmpty() {
+		return
+	}
+	config.Clients = FilterClients(config.Clients, func(client *Client) bool {
+		return f.Matches(client, config)
+	})
+
+	if len(f.Targets) > 0 {
+		var groups []*TargetGroup
+		for _, group := range config.Targets {
+			filtered := *group
+			filtered.Has = nil
+			filtered.multipleTargets = group.hasSubHeadings()
+			for _, target := range group.Has {
+				if f.hasTarget(target.Name) {
+					filtered.Has = append(filtered.Has, target)
+				}
+			}
+			if len(filtered.Has) > 0 {
+				groups = append(groups, &filtered)
+			}
+		}
+		config.Targets = groups
+	}
+
+	if len(f.Types) > 0 {
+		types := make(ClientTypes, 0, len(config.Types))
+		for _, t := range config.Types {
+			filtered := *t
+			filtered.Section = t.Section && f.hasType(t)
+			types = append(types, &filtered)
+		}
+		config.Types = types
+	}
+}
@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestClientFilterSingleTarget(t *testing.T) {
+	config := testConfig()
+	filter := &ClientFilter{Targets: []string{"ios"}}
+	filter.Apply(config)
+
+	if len(config.Clients) != 1 || config.Clients[0].Name != "finamp" {
+		t.Fatalf("expected only finamp, got %d clients", len(config.Clients))
+	}
+	if len(config.Targets) != 1 || len(config.Targets[0].Has) != 1 {
+		t.Fatalf("expected a single group with a single target, got %d groups", len(config.Targets))
+	}
+
+	var buf bytes.Buffer
+	if err := CreateMarkdownDocument(&buf, config); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	// The group had multiple targets before filtering, so the remaining target keeps its heading
+	if !strings.Contains(out, "## Mobile\n\n### iOS\n") {
+		t.Errorf("expected the iOS sub-heading, got:\n%s", out)
+	}
+	if strings.Contains(out, "Android") || strings.Contains(out, "Browser") {
+		t.Errorf("expected other targets to be removed, got:\n%s", out)
+	}
+}
+
+func TestClientFilterEmptyResult(t *testing.T) {
+	config := testConfig()
+	filter := &ClientFilter{Targets: []string{"web"}, Types: []string{"Music"}}
+	filter.Apply(config)
+
+	if len(config.Clients) != 0 {
+		t.Errorf("expected no clients, got %d", len(config.Clients))
+	}
+
+	var buf bytes.Buffer
+	if err := CreateMarkdownDocument(&buf, config); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "](") {
+		t.Errorf("expected no client links, got:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,48 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GitHubIconKey is the icon key of GitHub downloads.
+// GitHub downloads without a URL link to the releases of the client's open-source repository.
+const GitHubIconKey = "github"
+
+// ParseGitHubRepo extracts the owner and repository name from a GitHub repository URL,
+// e.g. https://github.com/jellyfin/jellyfin-web. Query strings and fragments are ignored.
+func ParseGitHubRepo(url string) (owner, repo string, ok bool) {
+	url = strings.TrimSpace(url)
+	if i := strings.IndexAny(url, "?#"); i >= 0 {
+		url = url[:i]
+	}
+	for _, prefix := range []string{"https://", "http://", "www."} {
+		url, _ = cutPrefixFold(url, prefix)
+	}
+	if url, ok = cutPrefixFold(url, "github.com/"); !ok {
+		return "", "", false
+	}
+
+	parts := strings.Split(url, "/")
+	if len(parts) < 2 {
+		return "", "", false
+	}
+	owner, repo = parts[0], strings.TrimSuffix(parts[1], ".git")
+	if owner == "" || repo == "" {
+		return "", "", false
+	}
+	return owner, repo, true
+}
+
+// GitHubRepoURL returns the canonical URL of a GitHub repository.
+func GitHubRepoURL(owner, repo string) string {
+	return fmt.Sprintf("https://github.com/%s/%s", owner, repo)
+}
+
+// cutPrefixFold returns `s` without the case-insensitive `prefix` and whether it was found.
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+		return s[len(prefix):], true
+	}
+	return s, false
+}
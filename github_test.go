@@ -0,0 +1,34 @@
+package generator
+
+import "testing"
+
+func TestParseGitHubRepo(t *testing.T) {
+	tests := []struct {
+		url         string
+		owner, repo string
+		ok          bool
+	}{
+		{"https://github.com/jellyfin/jellyfin-web", "jellyfin", "jellyfin-web", true},
+		{"https://github.com/jellyfin/jellyfin-web/", "jellyfin", "jellyfin-web", true},
+		{"https://github.com/jellyfin/jellyfin-web.git", "jellyfin", "jellyfin-web", true},
+		{"https://github.com/jellyfin/jellyfin-web/tree/master/src", "jellyfin", "jellyfin-web", true},
+		{"https://github.com/jellyfin/jellyfin-web#readme", "jellyfin", "jellyfin-web", true},
+		{"https://github.com/jellyfin/jellyfin-web?tab=readme-ov-file", "jellyfin", "jellyfin-web", true},
+		{"https://GitHub.com/jellyfin/jellyfin-web", "jellyfin", "jellyfin-web", true},
+		{"HTTPS://www.github.com/jellyfin/jellyfin-web", "jellyfin", "jellyfin-web", true},
+		{"http://github.com/jellyfin/jellyfin-web", "jellyfin", "jellyfin-web", true},
+		{" github.com/jellyfin/jellyfin-web ", "jellyfin", "jellyfin-web", true},
+		{"https://github.com/jellyfin", "", "", false},
+		{"https://github.com/jellyfin/", "", "", false},
+		{"https://gitlab.com/jellyfin/jellyfin-web", "", "", false},
+		{"https://notgithub.com/jellyfin/jellyfin-web", "", "", false},
+		{"", "", "", false},
+	}
+	for _, tt := range tests {
+		owner, repo, ok := ParseGitHubRepo(tt.url)
+		if owner != tt.owner || repo != tt.repo || ok != tt.ok {
+			t.Errorf("ParseGitHubRepo(%q) = %q, %q, %v; expected %q, %q, %v",
+				tt.url, owner, repo, ok, tt.owner, tt.repo, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package generator
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// CreateHTMLDocument writes the client list as an HTML fragment with a <table> per section.
+// The table cells are generated by the same columns as the markdown document.
+func CreateHTMLDocument(writer io.Writer, config *ClientsConfig) error {
+	doc := &htmlWriter{Writer: writer, slugs: slugger{}}
+
+	if config.Title != "" {
+		if err := doc.heading(1, config.Title); err != nil {
+			return err
+		}
+	}
+	if err := doc.paragraphs(config.Intro); err != nil {
+		return err
+	}
+
+	if err := writeDocument(doc, config); err != nil {
+		return err
+	}
+
+	return doc.paragraphs(config.Outro)
+}
+
+// htmlWriter writes the elements of the HTML document.
+type htmlWriter struct {
+	io.Writer
+	slugs   slugger
+	anchors clientAnchors
+}
+
+// heading writes a heading with a GitHub-compatible anchor id.
+func (h *htmlWriter) heading(level int, text string) error {
+	_, err := fmt.Fprintf(h, "<h%d id=\"%s\">%s</h%d>\n", level, h.slugs.slug(text), markdownToHTML(text), level)
+	return err
+}
+
+// paragraphs writes each blank-line separated block of the markdown text as a paragraph.
+func (h *htmlWriter) paragraphs(text string) error {
+	for _, paragraph := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph == "" {
+			continue
+		}
+		if _, err := fmt.Fprintf(h, "<p>%s</p>\n", markdownToHTML(paragraph)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// list writes an unordered list of markdown items.
+func (h *htmlWriter) list(items []string) error {
+	if _, err := fmt.Fprintln(h, "<ul>"); err != nil {
+		return err
+	}
+	for _, item := range items {
+		if _, err := fmt.Fprintf(h, "<li>%s</li>\n", markdownToHTML(item)); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintln(h, "</ul>"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// table writes a client table using the configured columns.
+func (h *htmlWriter) table(clients []*Client, config *ClientsConfig) error {
+	columns, err := config.TableColumns()
+	if err != nil {
+		return err
+	}
+	clients, err = sortClients(clients, config.Sort)
+	if err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<table>\n<thead>\n<tr>")
+	for _, column := range columns {
+		sb.WriteString("<th>" + html.EscapeString(column.Header) + "</th>")
+	}
+	sb.WriteString("</tr>\n</thead>\n<tbody>\n")
+	for _, client := range clients {
+		view := h.anchors.resolve(client, config)
+		sb.WriteString("<tr>")
+		for _, column := range columns {
+			cell, err := column.Cell(view, config)
+			if err != nil {
+				return err
+			}
+			sb.WriteString("<td>" + markdownToHTML(cell) + "</td>")
+		}
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("</tbody>\n</table>\n")
+
+	_, err = io.WriteString(h, sb.String())
+	return err
+}
+
+func (h *htmlWriter) environmentHeading() error {
+	return h.heading(1, "By Environment")
+}
+
+func (h *htmlWriter) targetGroupHeading(group *TargetGroup) error {
+	return h.heading(2, group.Display)
+}
+
+func (h *htmlWriter) targetHeading(target *Target) error {
+	return h.heading(3, target.Mapped)
+}
+
+func (h *htmlWriter) targetCaption(target *Target) error {
+	_, err := fmt.Fprintf(h, "<p><em>%s</em></p>\n", markdownToHTML(escapeMarkdown(target.Mapped)))
+	return err
+}
+
+func (h *htmlWriter) targetTable(clients []*Client, config *ClientsConfig) error {
+	return h.table(clients, config)
+}
+
+func (h *htmlWriter) typesHeading() error {
+	if _, err := fmt.Fprintln(h, "<hr>"); err != nil {
+		return err
+	}
+	return h.heading(1, "By Type")
+}
+
+func (h *htmlWriter) typeHeading(customType *ClientType) error {
+	return h.heading(2, customType.StringWithBadge())
+}
+
+func (h *htmlWriter) typeTable(clients []*Client, config *ClientsConfig) error {
+	return h.table(clients, config)
+}
+
+func (h *htmlWriter) clientCount(count int) error {
+	_, err := fmt.Fprintf(h, "<p><strong>%d %s</strong></p>\n", count, Select(count == 1, "client", "clients"))
+	return err
+}
+
+// alphabetSection writes a list of the clients, each linking to its website.
+func (h *htmlWriter) alphabetSection(clients []*Client) error {
+	if _, err := fmt.Fprintln(h, "<hr>"); err != nil {
+		return err
+	}
+	if err := h.heading(1, "By Alphabet"); err != nil {
+		return err
+	}
+	items := make([]string, 0, len(clients))
+	for _, client := range clients {
+		items = append(items, fmt.Sprintf("[%s](%s)", escapeMarkdown(client.DisplayName()), ResolveClient(client).WebsiteURL))
+	}
+	return h.list(items)
+}
+
+func (h *htmlWriter) legend(types ClientTypes) error {
+	if _, err := fmt.Fprintln(h, "<hr>"); err != nil {
+		return err
+	}
+	var items []string
+	for _, customType := range types {
+		if customType.Badge != "" {
+			items = append(items, fmt.Sprintf("%s: ` %s `", escapeMarkdown(customType.String()), customType.Badge))
+		}
+	}
+	return h.list(items)
+}
+
+// markdownToHTML converts the inline markdown generated for table cells and headings to HTML.
+// It supports backslash escapes, code spans, links, images, bold and strikethrough text.
+// Inline HTML, such as the <picture> elements of icons, is passed through unchanged.
+func markdownToHTML(markdown string) string {
+	var sb strings.Builder
+	var strong, del bool
+
+	for i := 0; i < len(markdown); {
+		rest := markdown[i:]
+		switch {
+		case rest[0] == '\\' && len(rest) > 1:
+			sb.WriteString(html.EscapeString(rest[1:2]))
+			i += 2
+		case rest[0] == '`':
+			end := strings.IndexByte(rest[1:], '`')
+			if end < 0 {
+				sb.WriteByte('`')
+				i++
+				continue
+			}
+			code := rest[1 : end+1]
+			if len(code) > 1 && code[0] == ' ' && code[len(code)-1] == ' ' {
+				code = code[1 : len(code)-1]
+			}
+			sb.WriteString("<code>" + html.EscapeString(code) + "</code>")
+			i += end + 2
+		case strings.HasPrefix(rest, "~~"):
+			sb.WriteString(Select(del, "</del>", "<del>"))
+			del = !del
+			i += 2
+		case strings.HasPrefix(rest, "**"):
+			sb.WriteString(Select(strong, "</strong>", "<strong>"))
+			strong = !strong
+			i += 2
+		case strings.HasPrefix(rest, "!["):
+			text, url, n, ok := parseMarkdownLink(rest[1:])
+			if !ok {
+				sb.WriteByte('!')
+				i++
+				continue
+			}
+			sb.WriteString(fmt.Sprintf(`<img src="%s" alt="%s">`, html.EscapeString(url), html.EscapeString(text)))
+			i += n + 1
+		case rest[0] == '[':
+			text, url, n, ok := parseMarkdownLink(rest)
+			if !ok {
+				sb.WriteByte('[')
+				i++
+				continue
+			}
+			sb.WriteString(fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(url), markdownToHTML(text)))
+			i += n
+		default:
+			sb.WriteByte(rest[0])
+			i++
+		}
+	}
+
+	if strong {
+		sb.WriteString("</strong>")
+	}
+	if del {
+		sb.WriteString("</del>")
+	}
+	return sb.String()
+}
+
+// parseMarkdownLink parses a `[text](url)` link at the start of `markdown`.
+// It returns the link text, the URL and the number of bytes consumed.
+func parseMarkdownLink(markdown string) (text, url string, n int, ok bool) {
+	textEnd := matchingBracket(markdown, '[', ']')
+	if textEnd < 0 || textEnd+1 >= len(markdown) || markdown[textEnd+1] != '(' {
+		return "", "", 0, false
+	}
+	urlEnd := matchingBracket(markdown[textEnd+1:], '(', ')')
+	if urlEnd < 0 {
+		return "", "", 0, false
+	}
+	urlEnd += textEnd + 1
+	return markdown[1:textEnd], markdown[textEnd+2 : urlEnd], urlEnd + 1, true
+}
+
+// matchingBracket returns the index of the bracket closing the one at the start of `s`,
+// skipping backslash-escaped characters, or -1 if it is not closed.
+func matchingBracket(s string, opening, closing byte) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case opening:
+			depth++
+		case closing:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// checkGolden compares `got` with the golden file `name` in testdata, rewriting it with -update.
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s (run with -update to accept):\n%s", path, got)
+	}
+}
+
+func TestCreateHTMLDocument(t *testing.T) {
+	config := testConfig()
+	config.Sections = []string{SectionAlphabet}
+
+	var buf bytes.Buffer
+	if err := CreateHTMLDocument(&buf, config); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "document.html", buf.Bytes())
+}
+
+func TestMarkdownToHTML(t *testing.T) {
+	tests := []struct {
+		markdown string
+		want     string
+	}{
+		{"plain & <text>", "plain & <text>"},
+		{`escaped \* \| \[`, "escaped * | ["},
+		{`\<b\>`, "&lt;b&gt;"},
+		{"` 🎵 `", "<code>🎵</code>"},
+		{"`a<b`", "<code>a&lt;b</code>"},
+		{"unclosed `code", "unclosed `code"},
+		{"**bold** and ~~gone~~", "<strong>bold</strong> and <del>gone</del>"},
+		{"**unclosed", "<strong>unclosed</strong>"},
+		{"[Jellyfin](https://jellyfin.org/?a=1&b=2)", `<a href="https://jellyfin.org/?a=1&amp;b=2">Jellyfin</a>`},
+		{"[~~Old~~ ` ⚠️ `](https://example.com)", `<a href="https://example.com"><del>Old</del> <code>⚠️</code></a>`},
+		{"[![img](icons/play.png)](https://play.google.com)", `<a href="https://play.google.com"><img src="icons/play.png" alt="img"></a>`},
+		{`[a \] b](https://example.com/(x))`, `<a href="https://example.com/(x)">a ] b</a>`},
+		{"[not a link] (x)", "[not a link] (x)"},
+		{"![alone", "![alone"},
+		{`<picture><img src="a.png"></picture>`, `<picture><img src="a.png"></picture>`},
+	}
+	for _, tt := range tests {
+		if got := markdownToHTML(tt.markdown); got != tt.want {
+			t.Errorf("markdownToHTML(%q) = %q, expected %q", tt.markdown, got, tt.want)
+		}
+	}
+}
+
+func TestCreateHTMLDocumentSharesSections(t *testing.T) {
+	config := testConfig()
+	config.Targets[1].Hidden = true
+	config.ShowCounts = true
+
+	var buf bytes.Buffer
+	if err := CreateHTMLDocument(&buf, config); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "Browser") {
+		t.Errorf("expected the hidden target group to be omitted, got:\n%s", out)
+	}
+	if !strings.Contains(out, "<h3 id=\"android\">Android</h3>\n<p><strong>2 clients</strong></p>\n<table>") {
+		t.Errorf("expected the client count before the table, got:\n%s", out)
+	}
+}
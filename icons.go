@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultIconsDir is the directory containing the client icon files.
+const DefaultIconsDir = "assets/clients/icons"
+
+// isLocalPath returns true if `path` refers to a file in the repository instead of a remote URL.
+func isLocalPath(path string) bool {
+	return path != "" && !strings.Contains(path, "://") && !strings.HasPrefix(path, "data:")
+}
+
+// AssetURL prefixes a local asset path with the configured base URL.
+// Remote URLs and paths are returned unchanged if no base URL is configured.
+func (c *ClientsConfig) AssetURL(path string) string {
+	if c.BaseURL == "" || !isLocalPath(path) {
+		return path
+	}
+	path = strings.TrimPrefix(filepath.ToSlash(filepath.Clean(path)), "/")
+	return strings.TrimSuffix(c.BaseURL, "/") + "/" + path
+}
+
+// resolveIcon returns a copy of the icon with all local paths prefixed with the configured base URL.
+func (c *ClientsConfig) resolveIcon(icon *HosterIcon) *HosterIcon {
+	resolved := *icon
+	resolved.Dark = c.AssetURL(icon.Dark)
+	resolved.Light = c.AssetURL(icon.Light)
+	resolved.Single = c.AssetURL(icon.Single)
+	return &resolved
+}
+
+// referencedIconFiles returns the cleaned paths of all local icon files referenced by the config.
+func referencedIconFiles(config *ClientsConfig) map[string]bool {
+	referenced := make(map[string]bool)
+	add := func(path string) {
+		if isLocalPath(path) {
+			referenced[filepath.Clean(path)] = true
+		}
+	}
+	for _, icon := range config.Icons {
+		add(icon.Dark)
+		add(icon.Light)
+		add(icon.Single)
+	}
+	for _, client := range config.Clients {
+		for _, list := range client.downloadLists() {
+			for _, hoster := range list.hosters {
+				add(hoster.IconURL)
+				add(hoster.IconURLDark)
+				add(hoster.IconURLLight)
+			}
+		}
+	}
+	return referenced
+}
+
+// CheckIcons returns all referenced icon files which don't exist and all files in `iconsDir`
+// which are not referenced by the config. Both lists are sorted.
+func CheckIcons(config *ClientsConfig, iconsDir string) (missing, unused []string, err error) {
+	referenced := referencedIconFiles(config)
+	for _, path := range SortedKeys(referenced) {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			missing = append(missing, path)
+		}
+	}
+
+	if _, err := os.Stat(iconsDir); os.IsNotExist(err) {
+		return missing, nil, nil
+	}
+	err = filepath.WalkDir(iconsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if !referenced[filepath.Clean(path)] {
+			unused = append(unused, path)
+		}
+		return nil
+	})
+	return missing, unused, err
+}
@@ -0,0 +1,76 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestCheckIcons(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"single.png", "download.png", "unused.png"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config := &ClientsConfig{
+		Icons: map[string]*HosterIcon{
+			"store":  {Single: filepath.Join(dir, "single.png")},
+			"remote": {Single: "https://example.com/icon.png"},
+		},
+		Clients: []*Client{
+			{
+				Name: "Client",
+				Downloads: []*Hoster{
+					{IconURL: filepath.Join(dir, "download.png"), URL: "https://example.com"},
+					{IconURL: filepath.Join(dir, "missing.png"), URL: "https://example.com"},
+				},
+			},
+		},
+	}
+
+	missing, unused, err := CheckIcons(config, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{filepath.Join(dir, "missing.png")}; !slices.Equal(missing, want) {
+		t.Errorf("expected missing %v, got %v", want, missing)
+	}
+	if want := []string{filepath.Join(dir, "unused.png")}; !slices.Equal(unused, want) {
+		t.Errorf("expected unused %v, got %v", want, unused)
+	}
+}
+
+func TestCheckIconsMissingDirectory(t *testing.T) {
+	missing, unused, err := CheckIcons(&ClientsConfig{}, filepath.Join(t.TempDir(), "icons"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(missing) > 0 || len(unused) > 0 {
+		t.Errorf("expected no missing or unused icons, got %v and %v", missing, unused)
+	}
+}
+
+func TestAssetURL(t *testing.T) {
+	tests := []struct {
+		baseURL string
+		path    string
+		want    string
+	}{
+		{"", "assets/icons/play.png", "assets/icons/play.png"},
+		{"https://cdn.example.com", "assets/icons/play.png", "https://cdn.example.com/assets/icons/play.png"},
+		{"https://cdn.example.com/", "./assets/../assets/icons/play.png", "https://cdn.example.com/assets/icons/play.png"},
+		{"https://cdn.example.com", "/assets/icons/play.png", "https://cdn.example.com/assets/icons/play.png"},
+		{"https://cdn.example.com", "https://example.com/play.png", "https://example.com/play.png"},
+		{"https://cdn.example.com", "data:image/png;base64,AAAA", "data:image/png;base64,AAAA"},
+		{"https://cdn.example.com", "", ""},
+	}
+	for _, tt := range tests {
+		config := &ClientsConfig{BaseURL: tt.baseURL}
+		if got := config.AssetURL(tt.path); got != tt.want {
+			t.Errorf("AssetURL(%q) with base %q = %q, expected %q", tt.path, tt.baseURL, got, tt.want)
+		}
+	}
+}
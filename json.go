@@ -0,0 +1,116 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// JSONDocument is the structured representation of the generated client list.
+type JSONDocument struct {
+	Clients []*JSONClient `json:"clients"`
+}
+
+// JSONClient is a client with all defaults resolved and its downloads rendered.
+type JSONClient struct {
+	Name             string          `json:"name"`
+	Description      string          `json:"description,omitempty"`
+	Website          string          `json:"website,omitempty"`
+	OpenSourceURL    string          `json:"oss,omitempty"`
+	License          string          `json:"license,omitempty"`
+	MinServerVersion string          `json:"min-server-version,omitempty"`
+	Official         bool            `json:"official"`
+	Beta             bool            `json:"beta"`
+	Deprecated       bool            `json:"deprecated"`
+	Free             bool            `json:"free"`
+	Paid             bool            `json:"paid"`
+	Targets          []string        `json:"targets"`
+	Types            []string        `json:"types"`
+	Downloads        []*JSONDownload `json:"downloads"`
+	BetaDownloads    []*JSONDownload `json:"beta-downloads"`
+	// DownloadGroups is omitted if the client has no download groups.
+	DownloadGroups []*JSONDownloadGroup `json:"download-groups,omitempty"`
+	// Community is omitted if the client has no community links.
+	Community []*CommunityLink `json:"community,omitempty"`
+}
+
+// JSONDownloadGroup is a rendered list of client downloads with a label.
+type JSONDownloadGroup struct {
+	Label     string          `json:"label"`
+	Downloads []*JSONDownload `json:"downloads"`
+}
+
+// JSONDownload is a rendered client download.
+type JSONDownload struct {
+	URL      string `json:"url"`
+	Text     string `json:"text,omitempty"`
+	Markdown string `json:"markdown"`
+}
+
+// CreateJSONDocument writes the resolved client list as indented JSON.
+func CreateJSONDocument(writer io.Writer, config *ClientsConfig) error {
+	clients, err := sortClients(config.Clients, config.Sort)
+	if err != nil {
+		return err
+	}
+
+	document := &JSONDocument{Clients: make([]*JSONClient, 0, len(clients))}
+	for _, client := range clients {
+		view := ResolveClient(client)
+		jsonClient := &JSONClient{
+			Name:             client.DisplayName(),
+			Description:      client.Description,
+			Website:          view.WebsiteURL,
+			OpenSourceURL:    client.OpenSourceURL,
+			License:          client.License,
+			MinServerVersion: client.MinServerVersion,
+			Official:         view.IsOfficial,
+			Beta:             view.IsBeta,
+			Deprecated:       view.IsDeprecated,
+			Free:             view.IsFree,
+			Paid:             view.IsPaid,
+			Targets:          Select(client.Targets != nil, client.Targets, []string{}),
+			Types:            Select(client.Types != nil, client.Types, []string{}),
+			Community:        client.Community,
+		}
+		if jsonClient.Downloads, err = jsonDownloads(client, downloadList{name: "download", hosters: client.Downloads}, config); err != nil {
+			return err
+		}
+		if jsonClient.BetaDownloads, err = jsonDownloads(client, downloadList{name: "beta download", hosters: client.BetaDownloads}, config); err != nil {
+			return err
+		}
+		for _, group := range client.DownloadGroups {
+			downloads, err := jsonDownloads(client, group.downloadList(), config)
+			if err != nil {
+				return err
+			}
+			jsonClient.DownloadGroups = append(jsonClient.DownloadGroups, &JSONDownloadGroup{
+				Label:     group.Label,
+				Downloads: downloads,
+			})
+		}
+		document.Clients = append(document.Clients, jsonClient)
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(document)
+}
+
+// jsonDownloads renders a list of client downloads.
+func jsonDownloads(client *Client, list downloadList, config *ClientsConfig) ([]*JSONDownload, error) {
+	downloads := make([]*JSONDownload, 0, len(list.hosters))
+	for i, hoster := range list.hosters {
+		markdown, err := processClientDownload(client, hoster, config)
+		if err != nil {
+			return nil, fmt.Errorf("client %q: %s #%d: %w", client.Name, list.name, i+1, err)
+		}
+		downloads = append(downloads, &JSONDownload{
+			URL:      downloadURL(client, hoster),
+			Text:     hoster.Text,
+			Markdown: markdown,
+		})
+	}
+	return downloads, nil
+}
@@ -0,0 +1,102 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestCreateJSONDocument(t *testing.T) {
+	config := testConfig()
+	config.Clients[1].MinServerVersion = "10.8"
+	config.Clients[1].Community = []*CommunityLink{
+		{Type: "matrix", URL: "https://matrix.to/#/#finamp:matrix.org"},
+	}
+
+	var buf bytes.Buffer
+	if err := CreateJSONDocument(&buf, config); err != nil {
+		t.Fatal(err)
+	}
+	var document JSONDocument
+	if err := json.Unmarshal(buf.Bytes(), &document); err != nil {
+		t.Fatal(err)
+	}
+
+	var finamp *JSONClient
+	for _, client := range document.Clients {
+		if client.Name == "finamp" {
+			finamp = client
+		}
+	}
+	if finamp == nil {
+		t.Fatal("expected finamp in the JSON document")
+	}
+	if finamp.MinServerVersion != "10.8" {
+		t.Errorf("expected min-server-version %q, got %q", "10.8", finamp.MinServerVersion)
+	}
+	if len(finamp.Community) != 1 || finamp.Community[0].Type != "matrix" {
+		t.Errorf("expected a matrix community link, got %v", finamp.Community)
+	}
+	if len(finamp.Downloads) != 2 || finamp.Downloads[1].Markdown != "[APK](https://example.com/finamp.apk)" {
+		t.Errorf("expected rendered downloads, got %v", finamp.Downloads)
+	}
+}
+
+func TestCreateJSONDocumentSmallConfig(t *testing.T) {
+	config := &ClientsConfig{
+		Icons: map[string]*HosterIcon{
+			"play":        {Single: "icons/play.png"},
+			GitHubIconKey: {Text: "GitHub"},
+		},
+		Clients: []*Client{
+			{
+				Name:          "Finamp",
+				Targets:       []string{"android"},
+				OpenSourceURL: "https://github.com/jmshrv/finamp",
+				Downloads: []*Hoster{
+					{Icon: "play", URL: "https://play.google.com/store/apps/details?id=finamp"},
+					{Text: "Releases", Icon: GitHubIconKey},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := CreateJSONDocument(&buf, config); err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "clients": [
+    {
+      "name": "Finamp",
+      "website": "https://github.com/jmshrv/finamp",
+      "oss": "https://github.com/jmshrv/finamp",
+      "official": false,
+      "beta": false,
+      "deprecated": false,
+      "free": true,
+      "paid": false,
+      "targets": [
+        "android"
+      ],
+      "types": [],
+      "downloads": [
+        {
+          "url": "https://play.google.com/store/apps/details?id=finamp",
+          "markdown": "[![img](icons/play.png)](https://play.google.com/store/apps/details?id=finamp)"
+        },
+        {
+          "url": "https://github.com/jmshrv/finamp/releases",
+          "text": "Releases",
+          "markdown": "[GitHub](https://github.com/jmshrv/finamp/releases)"
+        }
+      ],
+      "beta-downloads": []
+    }
+  ]
+}
+`
+	if buf.String() != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, buf.String())
+	}
+}
@@ -1,20 +1,28 @@
 package generator
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
+	"unicode/utf8"
 )
 
 const (
-	OfficialTypeKey = "Official"
-	BetaTypeKey     = "Beta"
+	OfficialTypeKey   = "Official"
+	BetaTypeKey       = "Beta"
+	DeprecatedTypeKey = "Deprecated"
+
+	// SectionAlphabet is the section key of the alphabetical client index.
+	SectionAlphabet = "alphabet"
 )
 
 // Markdown generates the markdown string for an icon.
-func (i *HosterIcon) Markdown(url string) string {
+func (i *HosterIcon) Markdown(url string) (string, error) {
 	if (i.Dark != "") != (i.Light != "") {
-		panic("use 'single' if only a single icon URL is available")
+		return "", errors.New("use 'single' if only a single icon URL is available")
 	}
 	if i.Dark != "" {
 		// Use picture element for alternate dark/light icons.
@@ -24,44 +32,111 @@ func (i *HosterIcon) Markdown(url string) string {
 			`<source media="(prefers-color-scheme: light)" srcset="%s">`+
 			`<img src="%s">`+
 			`</picture>`+
-			`</a>`, url, i.Dark, i.Light, i.Dark))
+			`</a>`, url, i.Dark, i.Light, i.Dark)), nil
 	}
 	if i.Text != "" {
 		// Use Markdown link with text if text is provided.
-		return fmt.Sprintf("[%s](%s)", i.Text, url)
+		return fmt.Sprintf("[%s](%s)", i.Text, url), nil
 	}
 	// Use default single image icon if no text is given.
-	return fmt.Sprintf("[![img](%s)](%s)", i.Single, url)
+	return fmt.Sprintf("[![img](%s)](%s)", i.Single, url), nil
 }
 
 // processClientDownloads generates markdown for client downloads.
-func processClientDownloads(client *Client, config *ClientsConfig) string {
+// Download groups follow on separate lines, each prefixed with its label.
+func processClientDownloads(client *Client, config *ClientsConfig) (string, error) {
+	markdown, err := processDownloadList(client, downloadList{name: "download", hosters: client.Downloads}, config)
+	if err != nil {
+		return "", err
+	}
+	for _, group := range client.DownloadGroups {
+		groupMarkdown, err := processDownloadList(client, group.downloadList(), config)
+		if err != nil {
+			return "", err
+		}
+		if markdown != "" {
+			markdown += "<br>"
+		}
+		markdown += fmt.Sprintf("**%s:** %s", escapeMarkdown(strings.TrimSpace(group.Label)), groupMarkdown)
+	}
+	return markdown, nil
+}
+
+// processClientBetaDownloads generates markdown for client beta downloads.
+func processClientBetaDownloads(client *Client, config *ClientsConfig) (string, error) {
+	return processDownloadList(client, downloadList{name: "beta download", hosters: client.BetaDownloads}, config)
+}
+
+// processDownloadList generates markdown for a list of client downloads.
+func processDownloadList(client *Client, list downloadList, config *ClientsConfig) (string, error) {
 	var sb strings.Builder
 
-	for _, hoster := range client.Downloads {
+	for i, hoster := range list.hosters {
 		if sb.Len() > 0 {
 			sb.WriteString(" ")
 		}
+		markdown, err := processClientDownload(client, hoster, config)
+		if err != nil {
+			return "", fmt.Errorf("client %q: %s #%d: %w", client.Name, list.name, i+1, err)
+		}
+		sb.WriteString(markdown)
+	}
 
-		if icon, ok := config.Icons[hoster.Icon]; ok && hoster.Icon != "" {
-			sb.WriteString(icon.Markdown(hoster.URL))
-		} else if hoster.IconURL != "" {
-			sb.WriteString((&HosterIcon{Single: hoster.IconURL}).Markdown(hoster.URL))
-		} else if hoster.Text != "" {
-			sb.WriteString(fmt.Sprintf("[%s](%s)", hoster.Text, hoster.URL))
-		} else {
-			panic("invalid download. specify either icon, icon-url, or text")
+	return strings.ReplaceAll(sb.String(), "\n", ""), nil
+}
+
+// downloadURL returns the URL of a client download.
+func downloadURL(client *Client, hoster *Hoster) string {
+	if hoster.URL == "" && hoster.Icon == GitHubIconKey {
+		// Default GitHub downloads to the releases of the open-source repository
+		if owner, repo, ok := ParseGitHubRepo(client.OpenSourceURL); ok {
+			return GitHubRepoURL(owner, repo) + "/releases"
 		}
 	}
+	return hoster.URL
+}
+
+// processClientDownload generates markdown for a single client download.
+func processClientDownload(client *Client, hoster *Hoster, config *ClientsConfig) (string, error) {
+	url := downloadURL(client, hoster)
+	if url == "" {
+		return "", errors.New("missing url")
+	}
 
-	return strings.ReplaceAll(sb.String(), "\n", "")
+	if hoster.Icon != "" {
+		icon, ok := config.Icons[hoster.Icon]
+		if !ok {
+			return "", fmt.Errorf("unknown icon %q", hoster.Icon)
+		}
+		markdown, err := config.resolveIcon(icon).Markdown(url)
+		if err != nil {
+			return "", fmt.Errorf("icon %q: %w", hoster.Icon, err)
+		}
+		return markdown, nil
+	} else if hoster.HasIconURL() {
+		return config.resolveIcon(hoster.URLIcon()).Markdown(url)
+	} else if hoster.Text != "" {
+		return fmt.Sprintf("[%s](%s)", hoster.Text, url), nil
+	}
+	return "", errors.New("invalid download: specify either icon, icon-url, or text")
 }
 
-func PrintTableHeader(writer io.Writer) error {
-	if _, err := fmt.Fprintln(writer, "| Name | OSS | Free | Paid | Downloads |"); err != nil {
+// PrintTableHeader prints the header and divider rows of a client table.
+func PrintTableHeader(writer io.Writer, config *ClientsConfig) error {
+	columns, err := config.TableColumns()
+	if err != nil {
+		return err
+	}
+
+	var header, divider strings.Builder
+	for _, column := range columns {
+		header.WriteString("| " + column.Header + " ")
+		divider.WriteString("| " + strings.Repeat("-", max(utf8.RuneCountInString(column.Header), 3)) + " ")
+	}
+	if _, err := fmt.Fprintln(writer, header.String()+"|"); err != nil {
 		return err
 	}
-	if _, err := fmt.Fprintln(writer, "| ---- | --- | ---- | ---- | --------- |"); err != nil {
+	if _, err := fmt.Fprintln(writer, divider.String()+"|"); err != nil {
 		return err
 	}
 	return nil
@@ -73,167 +148,273 @@ func PrintClientTable(
 	identifierClientMap map[string][]*Client,
 	config *ClientsConfig,
 ) error {
-	if err := PrintTableHeader(writer); err != nil {
+	return (&documentWriter{Writer: writer}).clientTable(clientsOfTarget(identifierClientMap, has), config)
+}
+
+// PrintClientTableRow prints a single row of the client table.
+func PrintClientTableRow(writer io.Writer, client *Client, config *ClientsConfig) error {
+	row, err := RenderClientRow(client, config)
+	if err != nil {
 		return err
 	}
-	for _, client := range identifierClientMap[strings.ToLower(strings.TrimSpace(has))] {
-		if err := PrintClientTableRow(writer, client, config); err != nil {
-			return err
+	_, err = fmt.Fprintln(writer, row)
+	return err
+}
+
+// RenderClientRow returns a single row of the client table, without a trailing newline.
+func RenderClientRow(client *Client, config *ClientsConfig) (string, error) {
+	return renderClientViewRow(ResolveClient(client), config)
+}
+
+// renderClientViewRow returns a single row of the client table for an already resolved client.
+func renderClientViewRow(view *ClientView, config *ClientsConfig) (string, error) {
+	columns, err := config.TableColumns()
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	for _, column := range columns {
+		cell, err := column.Cell(view, config)
+		if err != nil {
+			return "", err
 		}
+		sb.WriteString("| " + escapeTableCell(cell) + " ")
 	}
-	return nil
+	sb.WriteString("|")
+	return sb.String(), nil
 }
 
-// PrintClientTableRow prints a single row of the client table.
-func PrintClientTableRow(writer io.Writer, client *Client, config *ClientsConfig) error {
-	if client.Official == nil && strings.HasPrefix(client.OpenSourceURL, JellyfinOrgURL) {
-		client.Official = Ref(true) // Default to official if part of Jellyfin organization
+// markdownReplacer backslash-escapes inline markdown metacharacters.
+var markdownReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	"*", `\*`,
+	"_", `\_`,
+	"[", `\[`,
+	"]", `\]`,
+	"<", `\<`,
+	">", `\>`,
+	"~", `\~`,
+)
+
+// escapeMarkdown escapes user-supplied text so it is not interpreted as markdown.
+func escapeMarkdown(val string) string {
+	return markdownReplacer.Replace(val)
+}
+
+// tableCellReplacer escapes pipes and collapses line breaks in table cells.
+var tableCellReplacer = strings.NewReplacer(
+	"|", `\|`,
+	"\r\n", " ",
+	"\n", " ",
+	"\r", " ",
+)
+
+// escapeTableCell makes a value safe to be placed in a single markdown table cell.
+func escapeTableCell(val string) string {
+	return tableCellReplacer.Replace(val)
+}
+
+// addTypeBadge appends the badge of the type with the given key, if the type has a badge.
+func addTypeBadge(badges *[]string, key string, config *ClientsConfig) error {
+	t, ok := config.Types.FindType(key)
+	if !ok {
+		return fmt.Errorf("unknown type %q", key)
 	}
-	if client.Price.Free == nil && client.OpenSourceURL != "" {
-		client.Price.Free = Ref(true) // Default to free if open-source
+	if t.Badge != "" {
+		*badges = append(*badges, t.Badge)
 	}
+	return nil
+}
 
-	name := client.Name
-	oss := Select(client.OpenSourceURL != "", GoodTrue, BadFalse)
-	free := Select(DerefDef(client.Price.Free, false), GoodTrue, BadFalse)
-	paid := Select(DerefDef(client.Price.Paid, false), BadTrue, GoodFalse)
-	websiteURL := Select(client.Website != "", client.Website, client.OpenSourceURL)
-	downloadsMarkdown := processClientDownloads(client, config)
+// CreateMarkdownDocument writes the markdown document to `writer`.
+// The output is buffered and flushed once the document is complete.
+func CreateMarkdownDocument(writer io.Writer, config *ClientsConfig) error {
+	buffered := bufio.NewWriter(writer)
+	if err := writeMarkdownDocument(buffered, config); err != nil {
+		return err
+	}
+	return buffered.Flush()
+}
 
-	var badges []string
-	if Deref(client.Official) {
-		addTypeBadge(&badges, OfficialTypeKey, config)
+// writeMarkdownDocument writes the title, the optional table of contents, the document body and the outro.
+func writeMarkdownDocument(writer io.Writer, config *ClientsConfig) error {
+	if config.Title != "" {
+		if _, err := fmt.Fprintf(writer, "# %s\n\n", config.Title); err != nil {
+			return err
+		}
 	}
-	if Deref(client.Beta) {
-		addTypeBadge(&badges, BetaTypeKey, config)
+	if intro := strings.TrimSpace(config.Intro); intro != "" {
+		if _, err := fmt.Fprintf(writer, "%s\n\n", intro); err != nil {
+			return err
+		}
 	}
-	for _, t := range client.Types {
-		addTypeBadge(&badges, t, config)
+
+	if !config.TOC {
+		if err := writeDocument(&documentWriter{Writer: writer}, config); err != nil {
+			return err
+		}
+	} else {
+		// Render the body first to collect its headings for the table of contents
+		var body bytes.Buffer
+		doc := &documentWriter{Writer: &body}
+		if err := writeDocument(doc, config); err != nil {
+			return err
+		}
+		if err := PrintTableOfContents(writer, doc.headings); err != nil {
+			return err
+		}
+		if _, err := body.WriteTo(writer); err != nil {
+			return err
+		}
 	}
 
-	for _, b := range badges {
-		name += fmt.Sprintf(" ` %s `", b)
+	if outro := strings.TrimSpace(config.Outro); outro != "" {
+		if _, err := fmt.Fprintf(writer, "\n%s\n", outro); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	if _, err := fmt.Fprintf(
-		writer,
-		"| [%s](%s) | %s | %s | %s | %s |",
-		name,
-		websiteURL,
-		oss,
-		free,
-		paid,
-		downloadsMarkdown,
-	); err != nil {
+// documentWriter wraps the document output and records the headings written to it.
+type documentWriter struct {
+	io.Writer
+	headings []Heading
+	anchors  clientAnchors
+}
+
+// clientTable prints a client table including the client anchors, if enabled.
+func (d *documentWriter) clientTable(clients []*Client, config *ClientsConfig) error {
+	if err := PrintTableHeader(d, config); err != nil {
 		return err
 	}
-	if _, err := fmt.Fprintln(writer); err != nil {
+	clients, err := sortClients(clients, config.Sort)
+	if err != nil {
 		return err
 	}
+	for _, client := range clients {
+		row, err := renderClientViewRow(d.anchors.resolve(client, config), config)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(d, row); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func addTypeBadge(badges *[]string, key string, config *ClientsConfig) {
-	// find beta type
-	t, ok := config.Types.FindType(key)
-	if !ok {
-		panic("cannot find type with key: " + key)
+// clientAnchors tracks the first occurrence of each client in a document.
+type clientAnchors struct {
+	slugs slugger
+	ids   map[*Client]string
+}
+
+// resolve resolves the client and, if client anchors are enabled, sets either the anchor
+// of its first occurrence or the link to it.
+func (a *clientAnchors) resolve(client *Client, config *ClientsConfig) *ClientView {
+	view := ResolveClient(client)
+	if !config.ClientAnchors {
+		return view
 	}
-	if t.Badge != "" {
-		*badges = append(*badges, t.Badge)
+	if id, ok := a.ids[client]; ok {
+		view.AnchorLink = "#" + id
+		return view
 	}
+	if a.ids == nil {
+		a.slugs = slugger{}
+		a.ids = make(map[*Client]string)
+	}
+	view.Anchor = a.slugs.slug("client " + client.DisplayName())
+	a.ids[client] = view.Anchor
+	return view
 }
 
-func CreateMarkdownDocument(writer io.Writer, config *ClientsConfig) error {
-	// Process clients and create an identifier-client map
-	// e.g. iOS: [Swiftfin, Infuse, ...]
-	targetClientsMap := createIdentifierClientMap(config.Clients)
+// heading records a heading and returns its markdown representation.
+func (d *documentWriter) heading(level int, text string) string {
+	d.headings = append(d.headings, Heading{Level: level, Text: text})
+	return strings.Repeat("#", level) + " " + text
+}
+
+func (d *documentWriter) environmentHeading() error {
+	_, err := fmt.Fprintf(d, "%s\n", d.heading(1, "By Environment"))
+	return err
+}
 
-	if _, err := fmt.Fprint(writer, "# By Environment\n"); err != nil {
+func (d *documentWriter) targetGroupHeading(group *TargetGroup) error {
+	_, err := fmt.Fprintf(d, "%s\n\n", d.heading(2, group.Display))
+	return err
+}
+
+func (d *documentWriter) targetHeading(target *Target) error {
+	_, err := fmt.Fprintf(d, "%s\n\n", d.heading(3, target.Mapped))
+	return err
+}
+
+func (d *documentWriter) targetCaption(target *Target) error {
+	_, err := fmt.Fprintf(d, "_%s_\n\n", escapeMarkdown(target.Mapped))
+	return err
+}
+
+func (d *documentWriter) targetTable(clients []*Client, config *ClientsConfig) error {
+	if err := d.clientTable(clients, config); err != nil {
 		return err
 	}
+	_, err := fmt.Fprintln(d)
+	return err
+}
+
+func (d *documentWriter) typesHeading() error {
+	if _, err := fmt.Fprint(d, "\n---\n\n"); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(d, "%s\n", d.heading(1, "By Type"))
+	return err
+}
+
+func (d *documentWriter) typeHeading(customType *ClientType) error {
+	_, err := fmt.Fprintf(d, "\n%s\n\n", d.heading(2, customType.StringWithBadge()))
+	return err
+}
+
+func (d *documentWriter) typeTable(clients []*Client, config *ClientsConfig) error {
+	return d.clientTable(clients, config)
+}
 
-	// Generate and print the markdown content
-	for _, target := range config.Targets {
-		if _, err := fmt.Fprintf(writer, "## %s\n\n", target.Display); err != nil {
+func (d *documentWriter) clientCount(count int) error {
+	_, err := fmt.Fprintf(d, "**%d %s**\n\n", count, Select(count == 1, "client", "clients"))
+	return err
+}
+
+// alphabetSection prints a list of the clients, each linking to its website.
+func (d *documentWriter) alphabetSection(clients []*Client) error {
+	if _, err := fmt.Fprint(d, "\n---\n\n"); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(d, "%s\n\n", d.heading(1, "By Alphabet")); err != nil {
+		return err
+	}
+	for _, client := range clients {
+		websiteURL := ResolveClient(client).WebsiteURL
+		if _, err := fmt.Fprintf(d, "* [%s](%s)\n", escapeMarkdown(client.DisplayName()), websiteURL); err != nil {
 			return err
 		}
-		hasMultipleTargets := len(target.Has) > 1
-		for _, meta := range target.Has {
-			if hasMultipleTargets {
-				if _, err := fmt.Fprintf(writer, "### %s\n\n", meta.Mapped); err != nil {
-					return err
-				}
-			}
-			if err := PrintClientTable(writer, meta.Name, targetClientsMap, config); err != nil {
-				return err
-			}
-			if _, err := fmt.Fprintln(writer); err != nil {
-				return err
-			}
-		}
-	}
-
-	// Generate Type legend / sections
-	if len(config.Types) > 0 {
-		printHeader := true
-		for _, customType := range config.Types {
-			if !customType.Section {
-				continue
-			}
-			if printHeader {
-				printHeader = false
-
-				if _, err := fmt.Fprint(writer, "\n---\n\n"); err != nil {
-					return err
-				}
-				if _, err := fmt.Fprint(writer, "# By Type\n"); err != nil {
-					return err
-				}
-			}
-			// find all clients with this type
-			printTypeHeader := true
-			for _, client := range config.Clients {
-				// check if client belongs to type
-				belongs := false
-				for _, clientType := range client.Types {
-					if clientType == customType.Key {
-						belongs = true
-						break
-					}
-				}
-				if !belongs {
-					continue
-				}
-				if printTypeHeader {
-					printTypeHeader = false
-
-					if _, err := fmt.Fprintf(writer, "\n## %s\n\n", customType.StringWithBadge()); err != nil {
-						return err
-					}
-
-					if err := PrintTableHeader(writer); err != nil {
-						return err
-					}
-				}
-				if err := PrintClientTableRow(writer, client, config); err != nil {
-					return err
-				}
-			}
-		}
-
-		if _, err := fmt.Fprint(writer, "\n---\n\n"); err != nil {
-			return err
+	}
+	return nil
+}
+
+func (d *documentWriter) legend(types ClientTypes) error {
+	if _, err := fmt.Fprint(d, "\n---\n\n"); err != nil {
+		return err
+	}
+	for _, customType := range types {
+		if customType.Badge == "" {
+			continue
 		}
-		for _, customType := range config.Types {
-			if customType.Badge == "" {
-				continue
-			}
-			if _, err := fmt.Fprintf(writer, "* %s: ` %s `\n", customType.String(), customType.Badge); err != nil {
-				return err
-			}
+		if _, err := fmt.Fprintf(d, "* %s: ` %s `\n", customType.String(), customType.Badge); err != nil {
+			return err
 		}
 	}
-
 	return nil
 }
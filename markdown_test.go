@@ -0,0 +1,595 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testConfig returns a small config covering multi- and single-target groups and type sections.
+func testConfig() *ClientsConfig {
+	return &ClientsConfig{
+		Types: ClientTypes{
+			{Key: OfficialTypeKey, Badge: "🔹"},
+			{Key: BetaTypeKey, Badge: "🛠️"},
+			{Key: DeprecatedTypeKey, Badge: "⚠️"},
+			{Key: "Music", Badge: "🎵", Section: true},
+		},
+		Targets: []*TargetGroup{
+			{Key: "mobile", Display: "Mobile", Has: []*Target{
+				{Name: "android", Mapped: "Android"},
+				{Name: "ios", Mapped: "iOS"},
+			}},
+			{Key: "web", Display: "Browser", Has: []*Target{
+				{Name: "web", Mapped: "Web"},
+			}},
+		},
+		Icons: map[string]*HosterIcon{
+			GitHubIconKey: {Dark: "icons/gh-dark.png", Light: "icons/gh-light.png"},
+			"play":        {Single: "icons/play.png"},
+		},
+		Clients: []*Client{
+			{
+				Name:          "Jellyfin Web",
+				Targets:       []string{"web"},
+				OpenSourceURL: "https://github.com/jellyfin/jellyfin-web",
+				Downloads:     []*Hoster{{Icon: GitHubIconKey}},
+			},
+			{
+				Name:          "finamp",
+				Targets:       []string{"android", "ios"},
+				OpenSourceURL: "https://github.com/jmshrv/finamp",
+				Types:         []string{"Music"},
+				Downloads: []*Hoster{
+					{Icon: "play", URL: "https://play.google.com/store/apps/details?id=finamp"},
+					{Text: "APK", URL: "https://example.com/finamp.apk"},
+				},
+			},
+			{
+				Name:       "Abandoned",
+				Targets:    []string{"android"},
+				Website:    "https://example.com/abandoned",
+				Deprecated: Ref(true),
+				Price:      Price{Paid: Ref(true)},
+			},
+		},
+	}
+}
+
+func TestRenderClientRowMatchesPrintClientTableRow(t *testing.T) {
+	config := testConfig()
+	for _, client := range config.Clients {
+		row, err := RenderClientRow(client, config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := PrintClientTableRow(&buf, client, config); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != row+"\n" {
+			t.Errorf("client %q: expected %q, got %q", client.Name, buf.String(), row+"\n")
+		}
+	}
+}
+
+func TestPrintClientTable(t *testing.T) {
+	config := testConfig()
+
+	var want bytes.Buffer
+	if err := PrintTableHeader(&want, config); err != nil {
+		t.Fatal(err)
+	}
+	// sorted by name: Abandoned, finamp
+	for _, client := range []*Client{config.Clients[2], config.Clients[1]} {
+		if err := PrintClientTableRow(&want, client, config); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := PrintClientTable(&buf, "android", createIdentifierClientMap(config.Clients), config); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != want.String() {
+		t.Errorf("expected %q, got %q", want.String(), buf.String())
+	}
+}
+
+func TestRenderClientRowUnknownType(t *testing.T) {
+	config := testConfig()
+	client := &Client{Name: "Typo", Types: []string{"Musik"}, Website: "https://example.com"}
+
+	_, err := RenderClientRow(client, config)
+	if err == nil {
+		t.Fatal("expected an error for an unknown type")
+	}
+	if !strings.Contains(err.Error(), `unknown type "Musik"`) {
+		t.Errorf("expected unknown type error, got %v", err)
+	}
+}
+
+func TestCreateMarkdownDocumentUnknownType(t *testing.T) {
+	config := testConfig()
+	config.Types = config.Types[:1]
+
+	var buf bytes.Buffer
+	if err := CreateMarkdownDocument(&buf, config); err == nil {
+		t.Fatal("expected an error for the missing Deprecated type")
+	}
+}
+
+func TestCreateMarkdownDocumentIsDeterministic(t *testing.T) {
+	render := func() string {
+		var buf bytes.Buffer
+		if err := CreateMarkdownDocument(&buf, testConfig()); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	first := render()
+	if second := render(); first != second {
+		t.Errorf("expected identical output across runs, got:\n%s\nand:\n%s", first, second)
+	}
+	// Clients are sorted by name by default
+	if abandoned, finamp := strings.Index(first, "Abandoned"), strings.Index(first, "finamp"); abandoned > finamp {
+		t.Errorf("expected Abandoned before finamp in the Android table")
+	}
+}
+
+func TestTargetCaptions(t *testing.T) {
+	config := testConfig()
+	config.TargetCaptions = true
+	config.Targets[1].Has[0].Mapped = "Web_App*"
+
+	var buf bytes.Buffer
+	if err := CreateMarkdownDocument(&buf, config); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	// Single-target groups get an escaped caption
+	if !strings.Contains(out, "## Browser\n\n_Web\\_App\\*_\n\n| Name") {
+		t.Errorf("expected an escaped caption for the single-target group, got:\n%s", out)
+	}
+	// Multi-target groups keep their sub-headings without captions
+	if !strings.Contains(out, "## Mobile\n\n### Android\n\n| Name") || strings.Contains(out, "_Android_") {
+		t.Errorf("expected sub-headings for the multi-target group, got:\n%s", out)
+	}
+}
+
+func TestPrintClientTableRowEscapesTableCells(t *testing.T) {
+	config := testConfig()
+	config.Columns = []string{NameColumnKey, DescriptionColumnKey}
+	client := &Client{
+		Name:        "Pipe | Client",
+		Website:     "https://example.com",
+		Description: "First line\nsecond | line\r\nthird line",
+	}
+
+	var buf bytes.Buffer
+	if err := PrintClientTableRow(&buf, client, config); err != nil {
+		t.Fatal(err)
+	}
+	want := "| [Pipe \\| Client](https://example.com) | First line second \\| line third line |\n"
+	if buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+}
+
+func TestEscapeMarkdown(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{`back\slash`, `back\\slash`},
+		{"code `span`", "code \\`span\\`"},
+		{"*bold*", `\*bold\*`},
+		{"_italic_", `\_italic\_`},
+		{"[link]", `\[link\]`},
+		{"<html>", `\<html\>`},
+		{"~~strike~~", `\~\~strike\~\~`},
+		{"plain text 123", "plain text 123"},
+	}
+	for _, tt := range tests {
+		if got := escapeMarkdown(tt.text); got != tt.want {
+			t.Errorf("escapeMarkdown(%q) = %q, expected %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestNameCellEscapesClientName(t *testing.T) {
+	client := &Client{Name: "*Star* [Player]", Website: "https://example.com"}
+	got, err := nameCell(ResolveClient(client), testConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[\*Star\* \[Player\]](https://example.com)`; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestDownloadURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		oss    string
+		hoster *Hoster
+		want   string
+	}{
+		{"github default", "https://github.com/jmshrv/finamp", &Hoster{Icon: GitHubIconKey}, "https://github.com/jmshrv/finamp/releases"},
+		{"github url shape", "https://www.github.com/jmshrv/finamp.git/", &Hoster{Icon: GitHubIconKey}, "https://github.com/jmshrv/finamp/releases"},
+		{"explicit url", "https://github.com/jmshrv/finamp", &Hoster{Icon: GitHubIconKey, URL: "https://example.com"}, "https://example.com"},
+		{"non-github oss", "https://gitlab.com/owner/repo", &Hoster{Icon: GitHubIconKey}, ""},
+		{"other icon", "https://github.com/jmshrv/finamp", &Hoster{Icon: "play"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := downloadURL(&Client{OpenSourceURL: tt.oss}, tt.hoster); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDeprecatedClient(t *testing.T) {
+	config := testConfig()
+	abandoned := config.Clients[2]
+
+	got, err := nameCell(ResolveClient(abandoned), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "[~~Abandoned~~ ` ⚠️ `](https://example.com/abandoned)"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	config.Clients = FilterClients(config.Clients, func(client *Client) bool {
+		return !Deref(client.Deprecated)
+	})
+	var buf bytes.Buffer
+	if err := CreateMarkdownDocument(&buf, config); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "Abandoned") {
+		t.Errorf("expected deprecated client to be filtered, got:\n%s", buf.String())
+	}
+}
+
+func TestProcessClientDownloadIconURLVariants(t *testing.T) {
+	config := testConfig()
+	client := &Client{Name: "Client", OpenSourceURL: "https://github.com/owner/repo"}
+
+	fromIcon, err := processClientDownload(client, &Hoster{Icon: GitHubIconKey}, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromURLs, err := processClientDownload(client, &Hoster{
+		IconURLDark:  "icons/gh-dark.png",
+		IconURLLight: "icons/gh-light.png",
+		URL:          "https://github.com/owner/repo/releases",
+	}, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromIcon != fromURLs {
+		t.Errorf("expected icon-url-dark/light to render like the configured icon, got %q and %q", fromURLs, fromIcon)
+	}
+}
+
+func TestHosterIconMarkdown(t *testing.T) {
+	const url = "https://example.com"
+	tests := []struct {
+		name    string
+		icon    *HosterIcon
+		want    string
+		wantErr bool
+	}{
+		{"single", &HosterIcon{Single: "play.png"}, "[![img](play.png)](https://example.com)", false},
+		{"text", &HosterIcon{Text: "F-Droid"}, "[F-Droid](https://example.com)", false},
+		{"dark and light", &HosterIcon{Dark: "dark.png", Light: "light.png"},
+			`<a href="https://example.com"><picture>` +
+				`<source media="(prefers-color-scheme: dark)" srcset="dark.png">` +
+				`<source media="(prefers-color-scheme: light)" srcset="light.png">` +
+				`<img src="dark.png"></picture></a>`, false},
+		{"dark only", &HosterIcon{Dark: "dark.png", Single: "play.png"}, "", true},
+		{"light only", &HosterIcon{Light: "light.png"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.icon.Markdown(url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAlphabetSectionListsClientsOnce(t *testing.T) {
+	config := testConfig()
+	config.Sections = []string{SectionAlphabet}
+	config.Clients = append(config.Clients, &Client{Name: "Finamp ", Targets: []string{"web"}, Website: "https://example.com/finamp"})
+
+	var buf bytes.Buffer
+	if err := CreateMarkdownDocument(&buf, config); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	_, section, ok := strings.Cut(out, "# By Alphabet\n\n")
+	if !ok {
+		t.Fatalf("expected the alphabet section, got:\n%s", out)
+	}
+	section, _, _ = strings.Cut(section, "\n---")
+	want := "* [Abandoned](https://example.com/abandoned)\n" +
+		"* [finamp](https://github.com/jmshrv/finamp)\n" +
+		"* [Jellyfin Web](https://github.com/jellyfin/jellyfin-web)\n"
+	if section != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, section)
+	}
+}
+
+func TestClientRowsAreIdenticalAcrossSections(t *testing.T) {
+	config := testConfig()
+	finamp := config.Clients[1]
+	row, err := RenderClientRow(finamp, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := CreateMarkdownDocument(&buf, config); err != nil {
+		t.Fatal(err)
+	}
+	// finamp is listed under Android, iOS and the Music type section
+	if count := strings.Count(buf.String(), row+"\n"); count != 3 {
+		t.Errorf("expected 3 identical rows, got %d in:\n%s", count, buf.String())
+	}
+	if finamp.Official != nil || finamp.Price.Free != nil {
+		t.Error("expected rendering not to set defaults on the client")
+	}
+}
+
+func TestPrintClientTableRowIsIdempotent(t *testing.T) {
+	config := testConfig()
+	for _, client := range config.Clients {
+		before := *client
+
+		var first, second bytes.Buffer
+		if err := PrintClientTableRow(&first, client, config); err != nil {
+			t.Fatal(err)
+		}
+		if err := PrintClientTableRow(&second, client, config); err != nil {
+			t.Fatal(err)
+		}
+		if first.String() != second.String() {
+			t.Errorf("client %q: expected identical rows, got %q and %q", client.Name, first.String(), second.String())
+		}
+		if !reflect.DeepEqual(before, *client) {
+			t.Errorf("client %q: expected the client to be unchanged, got %+v", client.Name, *client)
+		}
+	}
+}
+
+func TestShowCounts(t *testing.T) {
+	config := testConfig()
+	config.ShowCounts = true
+
+	var buf bytes.Buffer
+	if err := CreateMarkdownDocument(&buf, config); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"### Android\n\n**2 clients**\n\n",
+		"### iOS\n\n**1 client**\n\n",
+		"## Browser\n\n**1 client**\n\n",
+		"## ` 🎵 ` Music\n\n**1 client**\n\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestTitleIntroAndOutro(t *testing.T) {
+	config := testConfig()
+	config.Title = "Jellyfin Clients"
+	config.Intro = "  A list of clients.\n"
+	config.Outro = "Contributions welcome."
+
+	var buf bytes.Buffer
+	if err := CreateMarkdownDocument(&buf, config); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, "# Jellyfin Clients\n\nA list of clients.\n\n# By Environment\n") {
+		t.Errorf("expected the title and intro before the first section, got:\n%s", out)
+	}
+	if !strings.HasSuffix(out, "* Music: ` 🎵 `\n\nContributions welcome.\n") {
+		t.Errorf("expected the outro after the legend, got:\n%s", out)
+	}
+}
+
+func TestNoTitleKeepsHeadings(t *testing.T) {
+	var buf bytes.Buffer
+	if err := CreateMarkdownDocument(&buf, testConfig()); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, "# By Environment\n") {
+		t.Errorf("expected the document to start with the first section, got:\n%s", out)
+	}
+	if !strings.HasSuffix(out, "* Music: ` 🎵 `\n") {
+		t.Errorf("expected the document to end with the legend, got:\n%s", out)
+	}
+}
+
+func TestHiddenTargetGroup(t *testing.T) {
+	config := testConfig()
+	config.Targets[1].Hidden = true
+
+	if errs := ValidateConfig(config); len(errs) > 0 {
+		t.Errorf("expected targets of hidden groups to stay valid, got %v", errs)
+	}
+
+	var buf bytes.Buffer
+	if err := CreateMarkdownDocument(&buf, config); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	environment, _, _ := strings.Cut(out, "# By Type")
+	if strings.Contains(environment, "Browser") || strings.Contains(environment, "Jellyfin Web") {
+		t.Errorf("expected the hidden group and its clients to be omitted, got:\n%s", environment)
+	}
+	if !strings.Contains(environment, "## Mobile") {
+		t.Errorf("expected visible groups to be printed, got:\n%s", environment)
+	}
+}
+
+// countingWriter counts the calls to Write, e.g. the syscalls of a file.
+type countingWriter struct {
+	bytes.Buffer
+	writes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return w.Buffer.Write(p)
+}
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+// largeConfig returns the test config with `n` copies of its clients.
+func largeConfig(n int) *ClientsConfig {
+	config := testConfig()
+	clients := config.Clients
+	config.Clients = nil
+	for i := 0; i < n; i++ {
+		for _, client := range clients {
+			c := *client
+			c.Name = fmt.Sprintf("%s %d", client.Name, i)
+			config.Clients = append(config.Clients, &c)
+		}
+	}
+	return config
+}
+
+func TestCreateMarkdownDocumentIsBuffered(t *testing.T) {
+	config := largeConfig(10)
+
+	var unbuffered countingWriter
+	if err := writeMarkdownDocument(&unbuffered, config); err != nil {
+		t.Fatal(err)
+	}
+	var buffered countingWriter
+	if err := CreateMarkdownDocument(&buffered, config); err != nil {
+		t.Fatal(err)
+	}
+
+	if buffered.String() != unbuffered.String() {
+		t.Errorf("expected unchanged output, got:\n%s\nexpected:\n%s", buffered.String(), unbuffered.String())
+	}
+	if buffered.writes >= unbuffered.writes {
+		t.Errorf("expected fewer writes than %d, got %d", unbuffered.writes, buffered.writes)
+	}
+}
+
+func TestCreateMarkdownDocumentReturnsFlushError(t *testing.T) {
+	if err := CreateMarkdownDocument(failingWriter{}, testConfig()); err == nil {
+		t.Error("expected the write error")
+	}
+}
+
+func BenchmarkCreateMarkdownDocument(b *testing.B) {
+	config := largeConfig(100)
+	for i := 0; i < b.N; i++ {
+		var w countingWriter
+		if err := CreateMarkdownDocument(&w, config); err != nil {
+			b.Fatal(err)
+		}
+		b.ReportMetric(float64(w.writes), "writes/op")
+	}
+}
+
+func TestClientAnchors(t *testing.T) {
+	config := testConfig()
+	config.ClientAnchors = true
+
+	var buf bytes.Buffer
+	if err := CreateMarkdownDocument(&buf, config); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	first := `<a id="client-finamp"></a>[finamp ` + "` 🎵 `" + `](https://github.com/jmshrv/finamp)`
+	later := "[finamp ` 🎵 `](#client-finamp)"
+	if strings.Count(out, first) != 1 {
+		t.Errorf("expected a single anchor %q, got:\n%s", first, out)
+	}
+	// finamp is listed under Android, iOS and Music
+	if strings.Count(out, later) != 2 {
+		t.Errorf("expected two links %q, got:\n%s", later, out)
+	}
+	if strings.Index(out, first) > strings.Index(out, later) {
+		t.Errorf("expected the anchor before the links, got:\n%s", out)
+	}
+}
+
+func TestClientAnchorsAreUnique(t *testing.T) {
+	config := testConfig()
+	config.ClientAnchors = true
+	config.Clients[2].Name = "finamp"
+
+	var buf bytes.Buffer
+	if err := CreateMarkdownDocument(&buf, config); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{`<a id="client-finamp"></a>`, `<a id="client-finamp-1"></a>`} {
+		if strings.Count(out, want) != 1 {
+			t.Errorf("expected a single %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestDownloadGroups(t *testing.T) {
+	config := testConfig()
+	client := &Client{
+		Name:      "Client",
+		Downloads: []*Hoster{{Icon: "play", URL: "https://play.google.com"}},
+		DownloadGroups: []*DownloadGroup{
+			{Label: "Stable", Downloads: []*Hoster{{Text: "GitHub", URL: "https://github.com/owner/repo/releases"}}},
+			{Label: " Nightly ", Downloads: []*Hoster{{Text: "GitLab", URL: "https://gitlab.com/owner/repo/-/releases"}}},
+		},
+	}
+
+	got, err := processClientDownloads(client, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "[![img](icons/play.png)](https://play.google.com)" +
+		"<br>**Stable:** [GitHub](https://github.com/owner/repo/releases)" +
+		"<br>**Nightly:** [GitLab](https://gitlab.com/owner/repo/-/releases)"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	client.Downloads = nil
+	got, err = processClientDownloads(client, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(got, "**Stable:** ") {
+		t.Errorf("expected the first group without a line break, got %q", got)
+	}
+}
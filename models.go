@@ -1,60 +1,203 @@
 package generator
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // Price indicates the cost of a client.
 type Price struct {
-	Free *bool `yaml:"free"`
-	Paid *bool `yaml:"paid"`
+	Free *bool `yaml:"free" json:"free"`
+	Paid *bool `yaml:"paid" json:"paid"`
 }
 
 // Hoster describes the hosting details for client downloads.
 type Hoster struct {
-	Icon    string `yaml:"icon"`
-	IconURL string `yaml:"icon-url"`
-	Text    string `yaml:"text"`
-	URL     string `yaml:"url"`
+	Icon    string `yaml:"icon" json:"icon"`
+	IconURL string `yaml:"icon-url" json:"icon-url"`
+	// IconURLDark and IconURLLight define alternate icons for dark and light color schemes.
+	IconURLDark  string `yaml:"icon-url-dark" json:"icon-url-dark"`
+	IconURLLight string `yaml:"icon-url-light" json:"icon-url-light"`
+	Text         string `yaml:"text" json:"text"`
+	URL          string `yaml:"url" json:"url"`
+}
+
+// URLIcon returns the icon defined by the icon URLs of the download.
+func (h *Hoster) URLIcon() *HosterIcon {
+	return &HosterIcon{
+		Single: h.IconURL,
+		Dark:   h.IconURLDark,
+		Light:  h.IconURLLight,
+	}
+}
+
+// HasIconURL returns true if the download defines at least one icon URL.
+func (h *Hoster) HasIconURL() bool {
+	return h.IconURL != "" || h.IconURLDark != "" || h.IconURLLight != ""
 }
 
 // Client defines a client application for Jellyfin with its properties.
 type Client struct {
-	Name          string    `yaml:"name"`
-	Targets       []string  `yaml:"targets"`
-	Official      *bool     `yaml:"official"`
-	Beta          *bool     `yaml:"beta"`
-	Website       string    `yaml:"website"`
-	OpenSourceURL string    `yaml:"oss"`
-	Price         Price     `yaml:"price"`
-	Downloads     []*Hoster `yaml:"downloads"`
-	Types         []string  `yaml:"types"`
+	Name          string    `yaml:"name" json:"name"`
+	Targets       []string  `yaml:"targets" json:"targets"`
+	Official      *bool     `yaml:"official" json:"official"`
+	Beta          *bool     `yaml:"beta" json:"beta"`
+	Deprecated    *bool     `yaml:"deprecated" json:"deprecated"`
+	Website       string    `yaml:"website" json:"website"`
+	OpenSourceURL string    `yaml:"oss" json:"oss"`
+	Price         Price     `yaml:"price" json:"price"`
+	Downloads     []*Hoster `yaml:"downloads" json:"downloads"`
+	BetaDownloads []*Hoster `yaml:"beta-downloads" json:"beta-downloads"`
+	// DownloadGroups are additional downloads printed with a caption, e.g. "Nightly".
+	DownloadGroups []*DownloadGroup `yaml:"download-groups" json:"download-groups"`
+	Types          []string         `yaml:"types" json:"types"`
+	Description    string           `yaml:"description" json:"description"`
+	License        string           `yaml:"license" json:"license"`
+	// MinServerVersion is the oldest Jellyfin server version supported by the client, e.g. "10.8".
+	MinServerVersion string `yaml:"min-server-version" json:"min-server-version"`
+	// Community lists chat rooms and forums of the client.
+	Community []*CommunityLink `yaml:"community" json:"community"`
+}
+
+// DownloadGroup is a list of client downloads with a label.
+type DownloadGroup struct {
+	Label     string    `yaml:"label" json:"label"`
+	Downloads []*Hoster `yaml:"downloads" json:"downloads"`
+}
+
+// downloadList returns the downloads of the group, named by its label.
+func (g *DownloadGroup) downloadList() downloadList {
+	return downloadList{name: fmt.Sprintf("download %q", g.Label), hosters: g.Downloads}
+}
+
+// CommunityLink is a link to a community chat room or forum, e.g. of type matrix, discord or irc.
+type CommunityLink struct {
+	Type string `yaml:"type" json:"type"`
+	URL  string `yaml:"url" json:"url"`
+}
+
+// DisplayName returns the client name with surrounding whitespace trimmed
+// and internal runs of whitespace collapsed to single spaces.
+func (c *Client) DisplayName() string {
+	return strings.Join(strings.Fields(c.Name), " ")
+}
+
+// downloadList is a list of client downloads with the name used in error messages.
+type downloadList struct {
+	name    string
+	hosters []*Hoster
+}
+
+// downloadLists returns the stable, beta and grouped downloads of the client.
+// Empty download groups are skipped.
+func (c *Client) downloadLists() []downloadList {
+	lists := []downloadList{
+		{name: "download", hosters: c.Downloads},
+		{name: "beta download", hosters: c.BetaDownloads},
+	}
+	for _, group := range c.DownloadGroups {
+		if group == nil {
+			continue
+		}
+		lists = append(lists, group.downloadList())
+	}
+	return lists
+}
+
+// downloadCount returns the number of download links of the client.
+func (c *Client) downloadCount() int {
+	count := 0
+	for _, list := range c.downloadLists() {
+		count += len(list.hosters)
+	}
+	return count
+}
+
+// ClientView is a client with all defaults resolved, as used for rendering.
+type ClientView struct {
+	*Client
+	IsOfficial   bool
+	IsBeta       bool
+	IsDeprecated bool
+	IsOpenSource bool
+	IsFree       bool
+	IsPaid       bool
+	WebsiteURL   string
+	// Anchor is the id of the anchor placed at the first occurrence of the client in the document.
+	Anchor string
+	// AnchorLink links later occurrences of the client to the anchor of its first occurrence.
+	AnchorLink string
+}
+
+// ResolveClient returns the view of a client with all defaults applied.
+// The client itself is not modified.
+func ResolveClient(client *Client) *ClientView {
+	return &ClientView{
+		Client: client,
+		// Default to official if part of Jellyfin organization
+		IsOfficial:   DerefDef(client.Official, strings.HasPrefix(client.OpenSourceURL, JellyfinOrgURL)),
+		IsBeta:       Deref(client.Beta),
+		IsDeprecated: Deref(client.Deprecated),
+		IsOpenSource: client.OpenSourceURL != "",
+		// Default to free if open-source
+		IsFree:     DerefDef(client.Price.Free, client.OpenSourceURL != ""),
+		IsPaid:     Deref(client.Price.Paid),
+		WebsiteURL: Select(client.Website != "", client.Website, client.OpenSourceURL),
+	}
+}
+
+// TypeKeys returns the keys of all types of the client, including the implicit
+// official, beta and deprecated types.
+func (v *ClientView) TypeKeys() []string {
+	var keys []string
+	if v.IsOfficial {
+		keys = append(keys, OfficialTypeKey)
+	}
+	if v.IsBeta {
+		keys = append(keys, BetaTypeKey)
+	}
+	if v.IsDeprecated {
+		keys = append(keys, DeprecatedTypeKey)
+	}
+	return append(keys, v.Types...)
 }
 
 type Target struct {
-	Name   string `json:"name,omitempty"`
-	Mapped string `json:"mapped,omitempty"`
+	Name   string `yaml:"name" json:"name,omitempty"`
+	Mapped string `yaml:"mapped" json:"mapped,omitempty"`
 }
 
 // TargetGroup defines a group of targets for the clients.
 type TargetGroup struct {
-	Key     string    `yaml:"key"`
-	Display string    `yaml:"display"`
-	Has     []*Target `yaml:"has"`
+	Key     string    `yaml:"key" json:"key"`
+	Display string    `yaml:"display" json:"display"`
+	Has     []*Target `yaml:"has" json:"has"`
+	// Hidden excludes the group from the document while keeping its targets valid.
+	Hidden bool `yaml:"hidden" json:"hidden"`
+
+	// multipleTargets is set on groups which had multiple targets before filtering.
+	multipleTargets bool
+}
+
+// hasSubHeadings returns true if each target of the group is printed under its own heading.
+func (g *TargetGroup) hasSubHeadings() bool {
+	return len(g.Has) > 1 || g.multipleTargets
 }
 
 // HosterIcon represents configuration for icons that can be used in markdown output.
 type HosterIcon struct {
-	Light  string `yaml:"light"`
-	Dark   string `yaml:"dark"`
-	Single string `yaml:"single"`
-	Text   string `yaml:"text"`
+	Light  string `yaml:"light" json:"light"`
+	Dark   string `yaml:"dark" json:"dark"`
+	Single string `yaml:"single" json:"single"`
+	Text   string `yaml:"text" json:"text"`
 }
 
 // ClientType represents a client type, such as music or reader clients
 type ClientType struct {
-	Key     string `json:"key"`
-	Badge   string `json:"badge"`
-	Display string `json:"display"`
-	Section bool   `json:"section"`
+	Key     string `yaml:"key" json:"key"`
+	Badge   string `yaml:"badge" json:"badge"`
+	Display string `yaml:"display" json:"display"`
+	Section bool   `yaml:"section" json:"section"`
 }
 
 func (t ClientType) String() string {
@@ -75,10 +218,39 @@ type ClientTypes []*ClientType
 
 // ClientsConfig holds the configuration for all clients.
 type ClientsConfig struct {
-	Clients []*Client              `yaml:"clients"`
-	Targets []*TargetGroup         `yaml:"targets"`
-	Icons   map[string]*HosterIcon `yaml:"icons"`
-	Types   ClientTypes            `yaml:"types"`
+	Clients []*Client              `yaml:"clients" json:"clients"`
+	Targets []*TargetGroup         `yaml:"targets" json:"targets"`
+	Icons   map[string]*HosterIcon `yaml:"icons" json:"icons"`
+	Types   ClientTypes            `yaml:"types" json:"types"`
+
+	// Include lists additional config files which are merged into this config.
+	Include []string `yaml:"include" json:"include"`
+
+	// Title, Intro and Outro are printed at the top and bottom of the document.
+	Title string `yaml:"title" json:"title"`
+	Intro string `yaml:"intro" json:"intro"`
+	Outro string `yaml:"outro" json:"outro"`
+	// BaseURL is prepended to all relative icon paths, e.g. when rendering outside the repository.
+	BaseURL string `yaml:"base-url" json:"base-url"`
+	// Symbols overrides the default symbols of the OSS, Free and Paid columns by symbol key.
+	Symbols map[string]string `yaml:"symbols" json:"symbols"`
+	// Accessible adds "Yes" or "No" to the symbols of the OSS, Free and Paid columns.
+	Accessible bool `yaml:"accessible" json:"accessible"`
+	// Columns defines which table columns are printed and in which order.
+	Columns []string `yaml:"columns" json:"columns"`
+	// Sections lists additional document sections to print, e.g. "alphabet".
+	Sections []string `yaml:"sections" json:"sections"`
+	// Sort defines the order of clients within a table (name, none or downloads), sorted by name if empty.
+	Sort string `yaml:"sort" json:"sort"`
+	// TargetCaptions prints the mapped name of the target as a caption in groups with a single target.
+	TargetCaptions bool `yaml:"target-captions" json:"target-captions"`
+	// ClientAnchors places an anchor at the first occurrence of each client
+	// and links later occurrences to it instead of the website.
+	ClientAnchors bool `yaml:"client-anchors" json:"client-anchors"`
+	// ShowCounts prints the number of clients above each table.
+	ShowCounts bool `yaml:"show-counts" json:"show-counts"`
+	// TOC prepends a table of contents linking to all generated headings.
+	TOC bool `yaml:"toc" json:"toc"`
 }
 
 func (t ClientTypes) FindType(key string) (*ClientType, bool) {
@@ -89,3 +261,34 @@ func (t ClientTypes) FindType(key string) (*ClientType, bool) {
 	}
 	return nil, false
 }
+
+// FindTarget returns the target group and target matching the given client target string.
+func (c *ClientsConfig) FindTarget(name string) (*TargetGroup, *Target, bool) {
+	name = strings.TrimSpace(strings.ToLower(name))
+	for _, group := range c.Targets {
+		for _, target := range group.Has {
+			if strings.TrimSpace(strings.ToLower(target.Name)) == name {
+				return group, target, true
+			}
+		}
+	}
+	return nil, nil, false
+}
+
+// Symbol returns the configured symbol for the given symbol key, falling back to the default symbol.
+func (c *ClientsConfig) Symbol(key string) string {
+	if symbol, ok := c.Symbols[key]; ok {
+		return symbol
+	}
+	return DefaultSymbols[key]
+}
+
+// HasSection returns true if the additional document section with the given key is enabled.
+func (c *ClientsConfig) HasSection(key string) bool {
+	for _, section := range c.Sections {
+		if section == key {
+			return true
+		}
+	}
+	return false
+}
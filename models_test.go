@@ -0,0 +1,38 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDisplayName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Jellyfin Web", "Jellyfin Web"},
+		{"  Jellyfin Web", "Jellyfin Web"},
+		{"Jellyfin Web \n", "Jellyfin Web"},
+		{"Jellyfin   Media\tPlayer", "Jellyfin Media Player"},
+		{" \t ", ""},
+	}
+	for _, tt := range tests {
+		client := &Client{Name: tt.name}
+		if got := client.DisplayName(); got != tt.want {
+			t.Errorf("DisplayName(%q) = %q, expected %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNameCellUsesDisplayName(t *testing.T) {
+	config := testConfig()
+	client := &Client{Name: "  Jellyfin   Web ", Website: "https://example.com", Types: []string{OfficialTypeKey}}
+
+	row, err := RenderClientRow(client, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "| [Jellyfin Web ` 🔹 `](https://example.com) |"; !strings.HasPrefix(row, want) {
+		t.Errorf("expected row to start with %q, got %q", want, row)
+	}
+}
@@ -0,0 +1,76 @@
+package generator
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// JSONSchemaDraft is the JSON Schema dialect of the generated config schema.
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// ConfigSchema returns a JSON Schema describing the config file.
+func ConfigSchema() map[string]any {
+	schema := typeSchema(reflect.TypeOf(ClientsConfig{}))
+	schema["$schema"] = JSONSchemaDraft
+	schema["title"] = "Jellyfin clients config"
+
+	props := schema["properties"].(map[string]any)
+	props["sort"].(map[string]any)["enum"] = []string{SortNone, SortName, SortDownloads}
+
+	props["sections"].(map[string]any)["items"].(map[string]any)["enum"] = []string{SectionAlphabet}
+
+	props["columns"].(map[string]any)["items"].(map[string]any)["enum"] = SortedKeys(Columns)
+
+	props["symbols"].(map[string]any)["propertyNames"] = map[string]any{"enum": SortedKeys(DefaultSymbols)}
+
+	return schema
+}
+
+// WriteConfigSchema writes the indented JSON Schema of the config file.
+func WriteConfigSchema(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(ConfigSchema())
+}
+
+// typeSchema generates the JSON Schema of a type based on its json struct tags.
+func typeSchema(t reflect.Type) map[string]any {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return typeSchema(t.Elem())
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": typeSchema(t.Elem())}
+	case reflect.Struct:
+		props := make(map[string]any)
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			props[name] = typeSchema(field.Type)
+		}
+		return map[string]any{"type": "object", "properties": props, "additionalProperties": false}
+	default:
+		return map[string]any{}
+	}
+}
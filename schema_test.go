@@ -0,0 +1,42 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestWriteConfigSchema(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteConfigSchema(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var schema struct {
+		Schema     string `json:"$schema"`
+		Properties map[string]struct {
+			Enum  []string `json:"enum"`
+			Items struct {
+				Enum []string `json:"enum"`
+			} `json:"items"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &schema); err != nil {
+		t.Fatalf("expected valid JSON, got %v", err)
+	}
+	if schema.Schema != JSONSchemaDraft {
+		t.Errorf("expected $schema %q, got %q", JSONSchemaDraft, schema.Schema)
+	}
+
+	for _, key := range []string{"clients", "targets", "icons", "types", "include", "columns", "sections", "sort"} {
+		if _, ok := schema.Properties[key]; !ok {
+			t.Errorf("expected property %q", key)
+		}
+	}
+	if got := schema.Properties["columns"].Items.Enum; !slices.Equal(got, SortedKeys(Columns)) {
+		t.Errorf("expected all column keys, got %v", got)
+	}
+	if got := schema.Properties["sort"].Enum; !slices.Equal(got, []string{SortNone, SortName, SortDownloads}) {
+		t.Errorf("expected all sort modes, got %v", got)
+	}
+}
@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// TOCHeading is the heading of the generated table of contents.
+const TOCHeading = "Contents"
+
+// Heading is a section heading written to the generated document.
+type Heading struct {
+	Level int
+	Text  string
+}
+
+// codeSpanRegex matches inline code spans including their optional padding spaces.
+var codeSpanRegex = regexp.MustCompile("` ?([^`]*?) ?`")
+
+// HeadingSlug returns the GitHub-compatible anchor slug for a heading text.
+func HeadingSlug(text string) string {
+	text = codeSpanRegex.ReplaceAllString(text, "$1")
+
+	var sb strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '_':
+			sb.WriteRune(r)
+		case r == ' ':
+			sb.WriteRune('-')
+		}
+	}
+	return sb.String()
+}
+
+// slugger generates unique heading slugs, suffixing duplicates with -1, -2, ...
+type slugger map[string]int
+
+func (s slugger) slug(text string) string {
+	base := HeadingSlug(text)
+	slug := base
+	for {
+		n, seen := s[slug]
+		if !seen {
+			break
+		}
+		s[slug] = n + 1
+		slug = fmt.Sprintf("%s-%d", base, n+1)
+	}
+	s[slug] = 0
+	return slug
+}
+
+// PrintTableOfContents prints a nested list linking to all given headings.
+func PrintTableOfContents(writer io.Writer, headings []Heading) error {
+	slugs := slugger{}
+	if _, err := fmt.Fprintf(writer, "## %s\n\n", TOCHeading); err != nil {
+		return err
+	}
+	slugs.slug(TOCHeading)
+
+	for _, h := range headings {
+		indent := strings.Repeat("  ", max(h.Level-1, 0))
+		if _, err := fmt.Fprintf(writer, "%s* [%s](#%s)\n", indent, h.Text, slugs.slug(h.Text)); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintln(writer); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package generator
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestHeadingSlug(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"By Environment", "by-environment"},
+		{"Android TV", "android-tv"},
+		{"` 🎵 ` Music", "-music"},
+		{"C# & .NET (Desktop)", "c--net-desktop"},
+		{"snake_case-name", "snake_case-name"},
+		{"Ünïcödé", "ünïcödé"},
+	}
+	for _, tt := range tests {
+		if got := HeadingSlug(tt.text); got != tt.want {
+			t.Errorf("HeadingSlug(%q) = %q, expected %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestSluggerDeduplicates(t *testing.T) {
+	slugs := slugger{}
+	for i, want := range []string{"android", "android-1", "android-2"} {
+		if got := slugs.slug("Android"); got != want {
+			t.Errorf("slug #%d = %q, expected %q", i+1, got, want)
+		}
+	}
+	// A heading whose slug equals a generated suffix is de-duplicated as well
+	if got := slugs.slug("Android 1"); got != "android-1-1" {
+		t.Errorf("expected %q, got %q", "android-1-1", got)
+	}
+}
+
+func TestPrintTableOfContents(t *testing.T) {
+	headings := []Heading{
+		{Level: 1, Text: "By Environment"},
+		{Level: 2, Text: "Mobile"},
+		{Level: 3, Text: "Android"},
+		{Level: 2, Text: "TV"},
+		{Level: 3, Text: "Android"},
+		{Level: 1, Text: "Contents"},
+	}
+
+	var buf bytes.Buffer
+	if err := PrintTableOfContents(&buf, headings); err != nil {
+		t.Fatal(err)
+	}
+	want := `## Contents
+
+* [By Environment](#by-environment)
+  * [Mobile](#mobile)
+    * [Android](#android)
+  * [TV](#tv)
+    * [Android](#android-1)
+* [Contents](#contents-1)
+
+`
+	if buf.String() != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, buf.String())
+	}
+}
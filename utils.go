@@ -1,5 +1,12 @@
 package generator
 
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
 // Select returns `whenTrue` if `expr` is true, otherwise `whenFalse`.
 func Select[T any](expr bool, whenTrue, whenFalse T) T {
 	if expr {
@@ -29,3 +36,47 @@ func Deref[T any](what *T) T {
 	var def T
 	return def
 }
+
+// SortedKeys returns the keys of `m` in ascending order, for deterministic iteration over maps.
+func SortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// didYouMean returns a " (did you mean ...?)" suffix naming the candidate closest to `name`,
+// or an empty string if no candidate is similar enough.
+func didYouMean(name string, candidates []string) string {
+	best, bestDistance := "", len(name)/3+1
+	for _, candidate := range candidates {
+		if distance := levenshtein(strings.ToLower(name), strings.ToLower(candidate)); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %q?)", best)
+}
+
+// levenshtein returns the edit distance between `a` and `b`.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := Select(ra[i-1] == rb[j-1], 0, 1)
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
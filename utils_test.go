@@ -0,0 +1,22 @@
+package generator
+
+import "testing"
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"github", "github", 0},
+		{"githib", "github", 1},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"🎵", "🎶", 1},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, expected %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
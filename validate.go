@@ -0,0 +1,199 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// versionRegex matches semver-like versions such as "10", "10.8" or "v10.8.13".
+var versionRegex = regexp.MustCompile(`^v?\d+(\.\d+){0,2}$`)
+
+// ValidateConfig checks the config for structural problems which would otherwise only
+// surface while rendering. All problems found are returned at once.
+// Empty entries, e.g. a bare "-" in a list, are reported on their own,
+// as the remaining checks assume all entries are set.
+func ValidateConfig(config *ClientsConfig) []error {
+	if errs := validateEntries(config); len(errs) > 0 {
+		return errs
+	}
+
+	var errs []error
+
+	if _, err := config.TableColumns(); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := sortClients(nil, config.Sort); err != nil {
+		errs = append(errs, err)
+	}
+
+	for _, section := range config.Sections {
+		if section != SectionAlphabet {
+			errs = append(errs, fmt.Errorf("unknown section: %q%s", section, didYouMean(section, []string{SectionAlphabet})))
+		}
+	}
+
+	for _, key := range SortedKeys(config.Symbols) {
+		if _, ok := DefaultSymbols[key]; !ok {
+			errs = append(errs, fmt.Errorf("unknown symbol: %q", key))
+		}
+	}
+
+	for _, key := range SortedKeys(config.Icons) {
+		if icon := config.Icons[key]; (icon.Dark != "") != (icon.Light != "") {
+			errs = append(errs, fmt.Errorf("icon %q: use 'single' if only a single icon URL is available", key))
+		}
+	}
+
+	var targetNames, typeKeys []string
+	for _, group := range config.Targets {
+		for _, target := range group.Has {
+			targetNames = append(targetNames, target.Name)
+		}
+	}
+	for _, t := range config.Types {
+		typeKeys = append(typeKeys, t.Key)
+	}
+
+	for _, client := range config.Clients {
+		for _, target := range client.Targets {
+			if _, _, ok := config.FindTarget(target); !ok {
+				errs = append(errs, fmt.Errorf("client %q: unknown target %q%s",
+					client.Name, target, didYouMean(target, targetNames)))
+			}
+		}
+
+		for _, key := range ResolveClient(client).TypeKeys() {
+			if _, ok := config.Types.FindType(key); !ok {
+				errs = append(errs, fmt.Errorf("client %q: unknown type %q%s", client.Name, key, didYouMean(key, typeKeys)))
+			}
+		}
+
+		if client.MinServerVersion != "" && !versionRegex.MatchString(client.MinServerVersion) {
+			errs = append(errs, fmt.Errorf("client %q: invalid min-server-version %q", client.Name, client.MinServerVersion))
+		}
+
+		for i, group := range client.DownloadGroups {
+			if strings.TrimSpace(group.Label) == "" {
+				errs = append(errs, fmt.Errorf("client %q: download group #%d: missing label", client.Name, i+1))
+			}
+		}
+
+		for i, link := range client.Community {
+			if link.Type == "" || link.URL == "" {
+				errs = append(errs, fmt.Errorf("client %q: community link #%d: specify both type and url", client.Name, i+1))
+			}
+		}
+
+		for _, list := range client.downloadLists() {
+			for i, hoster := range list.hosters {
+				if hoster.Icon == "" && !hoster.HasIconURL() && hoster.Text == "" {
+					errs = append(errs, fmt.Errorf("client %q: invalid %s #%d: specify either icon, icon-url, or text",
+						client.Name, list.name, i+1))
+				}
+				if (hoster.IconURLDark != "") != (hoster.IconURLLight != "") {
+					errs = append(errs, fmt.Errorf("client %q: %s #%d: specify both icon-url-dark and icon-url-light",
+						client.Name, list.name, i+1))
+				}
+				if _, ok := config.Icons[hoster.Icon]; hoster.Icon != "" && !ok {
+					errs = append(errs, fmt.Errorf("client %q: %s #%d: unknown icon %q%s",
+						client.Name, list.name, i+1, hoster.Icon, didYouMean(hoster.Icon, SortedKeys(config.Icons))))
+				}
+				if hoster.URL == "" && !(hoster.Icon == GitHubIconKey && isGitHubRepo(client.OpenSourceURL)) {
+					errs = append(errs, fmt.Errorf("client %q: %s #%d: missing url", client.Name, list.name, i+1))
+				}
+			}
+		}
+	}
+
+	return errs
+}
+
+// validateEntries reports empty (null) entries of the config.
+func validateEntries(config *ClientsConfig) []error {
+	var errs []error
+
+	for _, key := range SortedKeys(config.Icons) {
+		if config.Icons[key] == nil {
+			errs = append(errs, fmt.Errorf("icon %q: empty", key))
+		}
+	}
+
+	for i, group := range config.Targets {
+		if group == nil {
+			errs = append(errs, fmt.Errorf("target group #%d: empty", i+1))
+			continue
+		}
+		for j, target := range group.Has {
+			if target == nil {
+				errs = append(errs, fmt.Errorf("target group %q: target #%d: empty", group.Key, j+1))
+			}
+		}
+	}
+
+	for i, t := range config.Types {
+		if t == nil {
+			errs = append(errs, fmt.Errorf("type #%d: empty", i+1))
+		}
+	}
+
+	for i, client := range config.Clients {
+		if client == nil {
+			errs = append(errs, fmt.Errorf("client #%d: empty entry", i+1))
+			continue
+		}
+		for j, group := range client.DownloadGroups {
+			if group == nil {
+				errs = append(errs, fmt.Errorf("client %q: download group #%d: empty", client.Name, j+1))
+			}
+		}
+		for j, link := range client.Community {
+			if link == nil {
+				errs = append(errs, fmt.Errorf("client %q: community link #%d: empty", client.Name, j+1))
+			}
+		}
+		for _, list := range client.downloadLists() {
+			for j, hoster := range list.hosters {
+				if hoster == nil {
+					errs = append(errs, fmt.Errorf("client %q: %s #%d: empty", client.Name, list.name, j+1))
+				}
+			}
+		}
+	}
+
+	return errs
+}
+
+// ValidateDownloads renders the downloads of all clients without writing any output.
+// It returns one error per client, listing all of its downloads which failed to render.
+// Empty entries are skipped, they are reported by ValidateConfig.
+func ValidateDownloads(config *ClientsConfig) []error {
+	var errs []error
+	for _, client := range config.Clients {
+		if client == nil {
+			continue
+		}
+		var clientErrs []error
+		for _, list := range client.downloadLists() {
+			for i, hoster := range list.hosters {
+				if hoster == nil {
+					continue
+				}
+				if _, err := processClientDownload(client, hoster, config); err != nil {
+					clientErrs = append(clientErrs, fmt.Errorf("%s #%d: %w", list.name, i+1, err))
+				}
+			}
+		}
+		if len(clientErrs) > 0 {
+			errs = append(errs, fmt.Errorf("client %q:\n%w", client.Name, errors.Join(clientErrs...)))
+		}
+	}
+	return errs
+}
+
+// isGitHubRepo returns true if `url` is a GitHub repository URL.
+func isGitHubRepo(url string) bool {
+	_, _, ok := ParseGitHubRepo(url)
+	return ok
+}
@@ -0,0 +1,229 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestValidateDownloadsReportsBrokenDownload(t *testing.T) {
+	config := &ClientsConfig{
+		Icons: map[string]*HosterIcon{
+			"play": {Single: "icons/play.png"},
+		},
+		Clients: []*Client{
+			{
+				Name: "Working",
+				Downloads: []*Hoster{
+					{Icon: "play", URL: "https://play.google.com"},
+				},
+			},
+			{
+				Name: "Broken",
+				Downloads: []*Hoster{
+					{Text: "APK", URL: "https://example.com/app.apk"},
+					{Icon: "playstore", URL: "https://play.google.com"},
+				},
+			},
+		},
+	}
+
+	errs := ValidateDownloads(config)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %d: %v", len(errs), errs)
+	}
+	msg := errs[0].Error()
+	for _, want := range []string{`client "Broken"`, `download #2`, `unknown icon "playstore"`} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("expected error to contain %q, got %q", want, msg)
+		}
+	}
+}
+
+func TestValidateDownloadsValidConfig(t *testing.T) {
+	config := &ClientsConfig{
+		Clients: []*Client{
+			{
+				Name:          "Client",
+				OpenSourceURL: "https://github.com/owner/repo",
+				Downloads: []*Hoster{
+					{Icon: GitHubIconKey},
+				},
+			},
+		},
+		Icons: map[string]*HosterIcon{
+			GitHubIconKey: {Text: "GitHub"},
+		},
+	}
+	if errs := ValidateDownloads(config); len(errs) > 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+}
+
+func TestValidateConfigMinServerVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		valid   bool
+	}{
+		{"", true},
+		{"10", true},
+		{"10.8", true},
+		{"v10.8.13", true},
+		{"ten", false},
+		{"10.8.13.1", false},
+		{">= 10.8", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			config := testConfig()
+			config.Clients[0].MinServerVersion = tt.version
+
+			errs := ValidateConfig(config)
+			if tt.valid && len(errs) > 0 {
+				t.Errorf("expected no errors, got %v", errs)
+			}
+			if !tt.valid && (len(errs) != 1 || !strings.Contains(errs[0].Error(), "invalid min-server-version")) {
+				t.Errorf("expected an invalid min-server-version error, got %v", errs)
+			}
+		})
+	}
+}
+
+func TestValidateConfigValid(t *testing.T) {
+	if errs := ValidateConfig(testConfig()); len(errs) > 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+}
+
+func TestValidateConfigRules(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(config *ClientsConfig)
+		want   string
+	}{
+		{"unknown column", func(c *ClientsConfig) { c.Columns = []string{"rating"} }, `unknown column: "rating"`},
+		{"unknown sort mode", func(c *ClientsConfig) { c.Sort = "stars" }, `unknown sort mode: "stars"`},
+		{"unknown section", func(c *ClientsConfig) { c.Sections = []string{"index"} }, `unknown section: "index"`},
+		{"unknown symbol", func(c *ClientsConfig) { c.Symbols = map[string]string{"yes": "Y"} }, `unknown symbol: "yes"`},
+		{"icon dark without light", func(c *ClientsConfig) { c.Icons["dark"] = &HosterIcon{Dark: "dark.png"} },
+			`icon "dark": use 'single'`},
+		{"unknown target", func(c *ClientsConfig) { c.Clients[0].Targets = []string{"xbox"} }, `unknown target "xbox"`},
+		{"unknown type", func(c *ClientsConfig) { c.Clients[0].Types = []string{"Video"} }, `unknown type "Video"`},
+		{"download group label", func(c *ClientsConfig) { c.Clients[0].DownloadGroups = []*DownloadGroup{{}} },
+			"download group #1: missing label"},
+		{"community link", func(c *ClientsConfig) { c.Clients[0].Community = []*CommunityLink{{Type: "matrix"}} },
+			"community link #1: specify both type and url"},
+		{"download without icon or text", func(c *ClientsConfig) {
+			c.Clients[0].Downloads = []*Hoster{{URL: "https://example.com"}}
+		}, "invalid download #1: specify either icon, icon-url, or text"},
+		{"download dark without light", func(c *ClientsConfig) {
+			c.Clients[0].Downloads = []*Hoster{{IconURLDark: "dark.png", URL: "https://example.com"}}
+		}, "download #1: specify both icon-url-dark and icon-url-light"},
+		{"unknown download icon", func(c *ClientsConfig) {
+			c.Clients[0].Downloads = []*Hoster{{Icon: "store", URL: "https://example.com"}}
+		}, `download #1: unknown icon "store"`},
+		{"missing download url", func(c *ClientsConfig) {
+			c.Clients[0].Downloads = []*Hoster{{Text: "APK"}}
+		}, "download #1: missing url"},
+		{"beta download", func(c *ClientsConfig) {
+			c.Clients[0].BetaDownloads = []*Hoster{{Text: "TestFlight"}}
+		}, "beta download #1: missing url"},
+		{"empty icon", func(c *ClientsConfig) { c.Icons["play"] = nil }, `icon "play": empty`},
+		{"empty client", func(c *ClientsConfig) { c.Clients = append(c.Clients, nil) }, "client #4: empty entry"},
+		{"empty target group", func(c *ClientsConfig) { c.Targets = append(c.Targets, nil) }, "target group #3: empty"},
+		{"empty target", func(c *ClientsConfig) { c.Targets[0].Has = append(c.Targets[0].Has, nil) },
+			"target #3: empty"},
+		{"empty type", func(c *ClientsConfig) { c.Types = append(c.Types, nil) }, "type #5: empty"},
+		{"empty download", func(c *ClientsConfig) { c.Clients[0].Downloads = []*Hoster{nil} }, "download #1: empty"},
+		{"empty download group", func(c *ClientsConfig) { c.Clients[0].DownloadGroups = []*DownloadGroup{nil} },
+			"download group #1: empty"},
+		{"empty community link", func(c *ClientsConfig) { c.Clients[0].Community = []*CommunityLink{nil} },
+			"community link #1: empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testConfig()
+			tt.modify(config)
+
+			errs := ValidateConfig(config)
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), tt.want) {
+				t.Errorf("expected a single error containing %q, got %v", tt.want, errs)
+			}
+		})
+	}
+}
+
+func TestValidateConfigReportsAllErrors(t *testing.T) {
+	config := testConfig()
+	config.Sort = "stars"
+	config.Clients[0].Targets = []string{"xbox"}
+	config.Clients[1].Types = []string{"Video"}
+
+	if errs := ValidateConfig(config); len(errs) != 3 {
+		t.Errorf("expected 3 errors, got %d: %v", len(errs), errs)
+	}
+}
+
+func TestValidateConfigSuggestions(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(config *ClientsConfig)
+		want   string
+	}{
+		{"near target", func(c *ClientsConfig) { c.Clients[0].Targets = []string{"andriod"} }, `(did you mean "android"?)`},
+		{"distant target", func(c *ClientsConfig) { c.Clients[0].Targets = []string{"playstation"} }, ""},
+		{"near type", func(c *ClientsConfig) { c.Clients[0].Types = []string{"music"} }, `(did you mean "Music"?)`},
+		{"distant type", func(c *ClientsConfig) { c.Clients[0].Types = []string{"Audiobooks"} }, ""},
+		{"near icon", func(c *ClientsConfig) { c.Clients[0].Downloads[0].Icon = "githib" }, `(did you mean "github"?)`},
+		{"distant icon", func(c *ClientsConfig) { c.Clients[0].Downloads[0].Icon = "fdroid" }, ""},
+		{"near column", func(c *ClientsConfig) { c.Columns = []string{"licence"} }, `(did you mean "license"?)`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testConfig()
+			tt.modify(config)
+
+			errs := ValidateConfig(config)
+			if len(errs) == 0 {
+				t.Fatal("expected an error")
+			}
+			msg := errs[0].Error()
+			if tt.want == "" && strings.Contains(msg, "did you mean") {
+				t.Errorf("expected no suggestion, got %q", msg)
+			}
+			if tt.want != "" && !strings.Contains(msg, tt.want) {
+				t.Errorf("expected %q in %q", tt.want, msg)
+			}
+		})
+	}
+}
+
+func TestValidateConfigIsDeterministic(t *testing.T) {
+	config := testConfig()
+	for _, key := range []string{"d", "a", "c", "b", "e"} {
+		config.Icons[key] = &HosterIcon{Dark: key + ".png"}
+	}
+	config.Symbols = map[string]string{"z": "Z", "x": "X", "y": "Y"}
+
+	errorStrings := func() []string {
+		var messages []string
+		for _, err := range ValidateConfig(config) {
+			messages = append(messages, err.Error())
+		}
+		return messages
+	}
+	first := errorStrings()
+	want := []string{`unknown symbol: "x"`, `unknown symbol: "y"`, `unknown symbol: "z"`}
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		want = append(want, fmt.Sprintf("icon %q: use 'single' if only a single icon URL is available", key))
+	}
+	if !slices.Equal(first, want) {
+		t.Errorf("expected errors in key order %q, got %q", want, first)
+	}
+	for i := 0; i < 10; i++ {
+		if got := errorStrings(); !slices.Equal(got, first) {
+			t.Fatalf("expected identical errors across runs, got %q and %q", first, got)
+		}
+	}
+}